@@ -0,0 +1,92 @@
+package harmony
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	transfertypes "github.com/cosmos/ibc-go/modules/apps/transfer/types"
+	"github.com/harmony-one/harmony/accounts/abi"
+)
+
+// maxAmountBits is the size of uint256, which is the type of amounts in ERC20 and ICS20Bank
+const maxAmountBits = 256
+
+// Decimals returns the decimals of a denom in denom_units, or 0 if it isn't configured.
+// Denoms of tokens received over IBC fall back to the decimals of their base denoms.
+func (c ChainConfig) Decimals(denom string) uint32 {
+	for _, d := range []string{denom, transfertypes.ParseDenomTrace(denom).BaseDenom} {
+		for _, u := range c.DenomUnits {
+			if strings.EqualFold(u.Denom, d) {
+				return u.Decimals
+			}
+		}
+	}
+	return 0
+}
+
+// ParseAmount parses an amount in the display unit such as "1.5", and returns it in the smallest unit
+func ParseAmount(s string, decimals uint32) (*big.Int, error) {
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+	if uint32(len(fraction)) > decimals {
+		return nil, fmt.Errorf("amount %q has more than %d decimals", s, decimals)
+	}
+	digits := integer + fraction + strings.Repeat("0", int(decimals)-len(fraction))
+	if integer == "" || strings.ContainsAny(digits, "+-") {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	amount, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	if amount.BitLen() > maxAmountBits {
+		return nil, fmt.Errorf("amount %q overflows uint%d", s, maxAmountBits)
+	}
+	return amount, nil
+}
+
+// FormatAmount formats an amount in the smallest unit in the display unit, trimming trailing zeros of the fraction
+func FormatAmount(amount *big.Int, decimals uint32) string {
+	s := amount.String()
+	if decimals == 0 {
+		return s
+	}
+	sign := ""
+	if amount.Sign() < 0 {
+		sign, s = "-", s[1:]
+	}
+	if pad := int(decimals) + 1 - len(s); pad > 0 {
+		s = strings.Repeat("0", pad) + s
+	}
+	integer, fraction := s[:len(s)-int(decimals)], strings.TrimRight(s[len(s)-int(decimals):], "0")
+	if fraction == "" {
+		return sign + integer
+	}
+	return sign + integer + "." + fraction
+}
+
+// packableAmount converts an amount to the Go type of the uint argument of a contract method,
+// returning an error instead of truncating it if it doesn't fit
+func packableAmount(arg abi.Argument, amount *big.Int) (interface{}, error) {
+	if arg.Type.T != abi.UintTy {
+		return nil, fmt.Errorf("argument %s is not uint but %s", arg.Name, arg.Type.String())
+	}
+	if amount.Sign() < 0 || amount.BitLen() > arg.Type.Size {
+		return nil, fmt.Errorf("amount %v doesn't fit in %s of argument %s", amount, arg.Type.String(), arg.Name)
+	}
+	switch arg.Type.Size {
+	case 8:
+		return uint8(amount.Uint64()), nil
+	case 16:
+		return uint16(amount.Uint64()), nil
+	case 32:
+		return uint32(amount.Uint64()), nil
+	case 64:
+		return amount.Uint64(), nil
+	default:
+		return amount, nil
+	}
+}
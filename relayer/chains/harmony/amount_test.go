@@ -0,0 +1,100 @@
+package harmony
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/harmony-one/harmony/accounts/abi"
+)
+
+func TestParseAmount(t *testing.T) {
+	e18, _ := new(big.Int).SetString("1000000000000000000", 10)
+	cases := []struct {
+		s        string
+		decimals uint32
+		expected *big.Int
+	}{
+		{"100", 0, big.NewInt(100)},
+		{"1.5", 6, big.NewInt(1500000)},
+		{"0.000001", 6, big.NewInt(1)},
+		{"1.", 2, big.NewInt(100)},
+		// beyond uint64
+		{"20", 18, new(big.Int).Mul(e18, big.NewInt(20))},
+	}
+	for _, c := range cases {
+		amount, err := ParseAmount(c.s, c.decimals)
+		if err != nil {
+			t.Errorf("%q: %v", c.s, err)
+			continue
+		}
+		if amount.Cmp(c.expected) != 0 {
+			t.Errorf("%q: expected %v, but got %v", c.s, c.expected, amount)
+		}
+		if s := FormatAmount(amount, c.decimals); strings.TrimSuffix(c.s, ".") != s {
+			t.Errorf("%v: expected %q, but got %q", amount, c.s, s)
+		}
+	}
+
+	for _, s := range []string{"", "1.5", "-1", "+1", ".5", "1e3", "0x10", strings.Repeat("9", 80)} {
+		if _, err := ParseAmount(s, 0); err == nil {
+			t.Errorf("%q must be rejected", s)
+		}
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	for _, c := range []struct {
+		amount   int64
+		decimals uint32
+		expected string
+	}{
+		{0, 6, "0"},
+		{1, 6, "0.000001"},
+		{1000000, 6, "1"},
+		{-1500000, 6, "-1.5"},
+	} {
+		if s := FormatAmount(big.NewInt(c.amount), c.decimals); s != c.expected {
+			t.Errorf("%d: expected %q, but got %q", c.amount, c.expected, s)
+		}
+	}
+}
+
+func TestDecimals(t *testing.T) {
+	c := ChainConfig{DenomUnits: []*DenomUnit{
+		{Denom: "stake", Decimals: 6},
+		{Denom: "0x000000000000000000000000000000000000000A", Decimals: 18},
+	}}
+	for denom, expected := range map[string]uint32{
+		"stake":                    6,
+		"transfer/channel-0/stake": 6,
+		"0x000000000000000000000000000000000000000a": 18,
+		"unknown": 0,
+	} {
+		if d := c.Decimals(denom); d != expected {
+			t.Errorf("%s: expected %d, but got %d", denom, expected, d)
+		}
+	}
+}
+
+func TestPackableAmount(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"f","inputs":[{"name":"narrow","type":"uint64"},{"name":"wide","type":"uint256"}]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	narrow, wide := parsed.Methods["f"].Inputs[0], parsed.Methods["f"].Inputs[1]
+	e18, _ := new(big.Int).SetString("1000000000000000000", 10)
+	large := new(big.Int).Mul(e18, big.NewInt(20))
+
+	v, err := packableAmount(narrow, big.NewInt(100))
+	if err != nil || v != uint64(100) {
+		t.Fatalf("unexpected result: %v, %v", v, err)
+	}
+	if _, err := packableAmount(narrow, large); err == nil {
+		t.Fatal("an amount beyond uint64 must be rejected instead of truncated")
+	}
+	v, err = packableAmount(wide, large)
+	if err != nil || v.(*big.Int).Cmp(large) != 0 {
+		t.Fatalf("unexpected result: %v, %v", v, err)
+	}
+}
@@ -38,21 +38,28 @@ const (
 )
 
 type Chain struct {
-	config  ChainConfig
-	chainId *sdkcommon.ChainID
+	// config is the config whose references are resolved, and rawConfig is the one as written
+	config    ChainConfig
+	rawConfig ChainConfig
+	chainId   *sdkcommon.ChainID
 
 	pathEnd  *core.PathEnd
 	homePath string
 	codec    codec.ProtoCodecMarshaler
 
-	keyStore *keystore.KeyStore
-	client   *Client
+	keyStore     *keystore.KeyStore
+	client       *Client
+	beaconClient *Client
 
 	ibcHostAbi    abi.ABI
 	ibcHandlerAbi abi.ABI
 	ibcHost       *ibchost.Ibchost
 	ibcHandler    *ibchandler.Ibchandler
 
+	headers *headerVersions
+
+	logCursors *logCursors
+
 	/* for demo convenience */
 	simpleTokenAbi       abi.ABI
 	ics20BankAbi         abi.ABI
@@ -64,13 +71,24 @@ type Chain struct {
 
 var _ core.ChainI = (*Chain)(nil)
 
-func NewChain(config ChainConfig) (*Chain, error) {
-	client := NewHarmonyClient(config.ShardRpcAddr)
+func NewChain(rawConfig ChainConfig) (*Chain, error) {
+	config, err := rawConfig.Resolve()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := config.RPCTimeoutDuration(); err != nil {
+		return nil, fmt.Errorf("invalid rpc_timeout: %w", err)
+	}
+	headers, err := newHeaderVersions(config.HeaderVersions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid header_versions: %w", err)
+	}
+	client := config.NewShardClient()
 	chainId, err := config.ChainID()
 	if err != nil {
 		return nil, err
 	}
-	ethClient, err := NewETHClient(config.ShardRpcAddr)
+	ethClient, err := client.ETHClient()
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +108,7 @@ func NewChain(config ChainConfig) (*Chain, error) {
 	if err != nil {
 		return nil, err
 	}
-	ics20TransferBank, err := ics20transferbank.NewIcs20transferbank(config.ICS20BankAddress(), ethClient)
+	ics20TransferBank, err := ics20transferbank.NewIcs20transferbank(config.ICS20TransferBankAddress(), ethClient)
 	if err != nil {
 		return nil, err
 	}
@@ -118,10 +136,14 @@ func NewChain(config ChainConfig) (*Chain, error) {
 
 	return &Chain{
 		config:               config,
+		rawConfig:            rawConfig,
 		chainId:              chainId,
 		client:               client,
+		beaconClient:         config.NewBeaconClient(),
 		ibcHost:              ibcHost,
 		ibcHandler:           ibcHandler,
+		headers:              headers,
+		logCursors:           newLogCursors(),
 		ibcHostAbi:           ibcHostAbi,
 		ibcHandlerAbi:        ibcHandlerAbi,
 		simpleToken:          simpleToken,
@@ -150,6 +172,13 @@ func (c *Chain) Init(homePath string, timeout time.Duration, codec codec.ProtoCo
 		}
 	}
 	c.keyStore = keyStore
+	// rpc_timeout takes precedence over the global timeout
+	if rt, _ := c.config.RPCTimeoutDuration(); rt > 0 {
+		timeout = rt
+	}
+	c.client.SetTimeout(timeout)
+	c.beaconClient.SetTimeout(timeout)
+	// the archive endpoints, which proofs out of the pruning window need, are detected on the first such query
 	return nil
 }
 
@@ -160,7 +189,7 @@ func (c *Chain) ChainID() string {
 
 // GetLatestHeight gets the chain for the latest height and returns it
 func (c *Chain) GetLatestHeight() (int64, error) {
-	bn, err := c.client.BlockNumber(context.TODO())
+	bn, err := c.client.BlockNumber(context.Background())
 	if err != nil {
 		return 0, err
 	}
@@ -197,6 +226,11 @@ func (c *Chain) Path() *core.PathEnd {
 	return c.pathEnd
 }
 
+// Config returns the config of the chain as written, whose references are left unresolved
+func (c *Chain) Config() ChainConfig {
+	return c.rawConfig
+}
+
 // StartEventListener ...
 func (c *Chain) StartEventListener(dst core.ChainI, strategy core.StrategyI) {
 	return
@@ -273,20 +307,23 @@ func (c *Chain) QueryConnection(height int64) (*conntypes.QueryConnectionRespons
 	return conntypes.NewQueryConnectionResponse(connectionEndToPB(conn), nil, clienttypes.NewHeight(0, uint64(height))), nil
 }
 
-var emptyChannelRes = chantypes.NewQueryChannelResponse(
-	chantypes.NewChannel(
-		chantypes.UNINITIALIZED,
-		chantypes.UNORDERED,
-		chantypes.NewCounterparty(
-			"port",
-			"channel",
+// emptyChannelRes returns the response for a channel which isn't initialized yet with the given ordering
+func emptyChannelRes(order chantypes.Order) *chantypes.QueryChannelResponse {
+	return chantypes.NewQueryChannelResponse(
+		chantypes.NewChannel(
+			chantypes.UNINITIALIZED,
+			order,
+			chantypes.NewCounterparty(
+				"port",
+				"channel",
+			),
+			[]string{},
+			"version",
 		),
-		[]string{},
-		"version",
-	),
-	[]byte{},
-	clienttypes.NewHeight(0, 0),
-)
+		[]byte{},
+		clienttypes.NewHeight(0, 0),
+	)
+}
 
 // QueryChannel returns the channel associated with a channelID
 func (c *Chain) QueryChannel(height int64) (chanRes *chantypes.QueryChannelResponse, err error) {
@@ -294,11 +331,20 @@ func (c *Chain) QueryChannel(height int64) (chanRes *chantypes.QueryChannelRespo
 	if err != nil {
 		return nil, err
 	} else if !found {
-		return emptyChannelRes, nil
+		return emptyChannelRes(orderFromString(c.pathEnd.Order)), nil
 	}
 	return chantypes.NewQueryChannelResponse(channelToPB(chann), nil, clienttypes.NewHeight(0, uint64(height))), nil
 }
 
+// QueryNextSequenceRecv returns the next sequence to be received on the channel
+func (c *Chain) QueryNextSequenceRecv(height int64) (*chantypes.QueryNextSequenceReceiveResponse, error) {
+	seq, err := c.ibcHost.GetNextSequenceRecv(c.CallOpts(context.Background(), height), c.pathEnd.PortID, c.pathEnd.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+	return chantypes.NewQueryNextSequenceReceiveResponse(seq, nil, clienttypes.NewHeight(0, uint64(height))), nil
+}
+
 // QueryPacketCommitment returns the packet commitment corresponding to a given sequence
 func (c *Chain) QueryPacketCommitment(height int64, seq uint64) (comRes *chantypes.QueryPacketCommitmentResponse, err error) {
 	commitment, found, err := c.ibcHost.GetPacketCommitment(c.CallOpts(context.Background(), height), c.pathEnd.PortID, c.pathEnd.ChannelID, seq)
@@ -1,14 +1,24 @@
 package harmony
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
 	"math/big"
+	"net/http"
+	"net/url"
 	"strconv"
+	"sync"
+	"time"
 
+	retry "github.com/avast/retry-go"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -22,16 +32,83 @@ const (
 	MethodEpochLastBlock = "hmyv2_epochLastBlock"
 	MethodGetEpoch       = "hmyv2_getEpoch"
 	MethodCall           = "hmyv2_call"
+	MethodGetBalance     = "eth_getBalance"
+	MethodChainID        = "hmyv2_chainId"
+
+	MethodGetTransactionReceipt = "hmyv2_getTransactionReceipt"
+	MethodGetTransactionByHash  = "hmyv2_getTransactionByHash"
+)
+
+const (
+	healthCheckInterval = 30 * time.Second
+	rpcRetryDelay       = 200 * time.Millisecond
 )
 
+// Client sends RPC requests to one of the endpoints of a shard.
+// It fails over to the next healthy endpoint when a request fails,
+// and checks the health of the endpoints periodically.
 type Client struct {
+	mu        sync.Mutex
+	endpoints []*endpoint
+	current   int
+	pinned    int
+	checkedAt time.Time
+	// the highest block number seen so far
+	latest uint64
+	// whether the archive endpoints have been detected
+	archiveDetected bool
+	// detectMu serializes the detection of the archive endpoints
+	detectMu sync.Mutex
+
+	opts ClientOptions
+}
+
+// ClientOptions configures the failover and the routing of requests of a client
+type ClientOptions struct {
+	// an endpoint is unhealthy if its latest block lags behind the others by more than this
+	MaxBlockLag uint64
+	// the number of retries of a failed request
+	MaxRetries uint
+	// the number of recent blocks whose state non-archive nodes keep
+	PruningWindow uint64
+	// the timeout of each request. 0 means no timeout
+	Timeout time.Duration
+}
+
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		MaxBlockLag:   defaultMaxBlockLag,
+		MaxRetries:    defaultRPCMaxRetries,
+		PruningWindow: defaultPruningWindow,
+	}
+}
+
+type endpoint struct {
+	url       string
 	messenger *sdkrpc.HTTPMessenger
+	healthy   bool
+	height    uint64
+	// whether the endpoint serves the state of blocks out of the pruning window
+	archive bool
 }
 
 func NewHarmonyClient(endpoint string) *Client {
-	messenger := sdkrpc.NewHTTPHandler(endpoint)
+	return NewHarmonyClientWithFailover([]string{endpoint}, DefaultClientOptions())
+}
+
+// NewHarmonyClientWithFailover returns a client of the given endpoints in order of preference
+func NewHarmonyClientWithFailover(urls []string, opts ClientOptions) *Client {
+	endpoints := make([]*endpoint, len(urls))
+	for i, addr := range urls {
+		endpoints[i] = &endpoint{
+			url:       addr,
+			messenger: sdkrpc.NewHTTPHandler(addr),
+			healthy:   true,
+		}
+	}
 	return &Client{
-		messenger: messenger,
+		endpoints: endpoints,
+		opts:      opts,
 	}
 }
 
@@ -43,11 +120,431 @@ func NewETHClient(endpoint string) (*ethclient.Client, error) {
 	return ethclient.NewClient(conn), nil
 }
 
+// ETHClient returns an Ethereum compatible client which shares the endpoints with this client,
+// so that contract calls follow the failover and the pinning of it
+func (c *Client) ETHClient() (*ethclient.Client, error) {
+	conn, err := rpc.DialHTTPWithClient(c.endpoints[0].url, &http.Client{
+		Transport: &failoverTransport{client: c, base: http.DefaultTransport},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(conn), nil
+}
+
+// SetTimeout sets the timeout of each request
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.opts.Timeout = timeout
+}
+
+// withTimeout returns a context which is canceled after the timeout of a request
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.timeoutContext(ctx)
+}
+
+// timeoutContext is the same as withTimeout, but it must be called with the lock held.
+func (c *Client) timeoutContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return contextWithTimeout(ctx, c.opts.Timeout)
+}
+
+// contextWithTimeout returns a context which is canceled after the timeout. 0 means no timeout
+func contextWithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// Pin fixes the current endpoint until the returned function is called,
+// so that a query and its proof at the given height are served by the same node.
+// If the height is out of the pruning window, an archive endpoint is pinned if available.
+// Failed requests are retried on the pinned endpoint instead of failing over.
+func (c *Client) Pin(height uint64) (unpin func()) {
+	c.refreshHealth(context.Background())
+	c.detectArchiveIfNeeded(context.Background(), height)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e := c.endpoints[c.current]; c.pinned == 0 && c.isHistorical(height) && !e.archive {
+		for i, a := range c.endpoints {
+			if a.healthy && a.archive {
+				c.current = i
+				break
+			}
+		}
+	}
+	c.pinned++
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.pinned--
+	}
+}
+
+func (c *Client) messenger(ctx context.Context) *txMessenger {
+	c.refreshHealth(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.endpoints[c.current]
+	return &txMessenger{HTTPMessenger: e.messenger, client: c, endpoint: e, ctx: ctx}
+}
+
+// txMessenger sends the requests of the Harmony SDK to a single endpoint, so that the nonce and the transaction of a
+// sender go to the same node. The HTTPMessenger of the SDK has no deadline, so each request is bounded by ctx and
+// the timeout of the client instead. The requests are neither retried nor failed over, as a transaction may be sent twice.
+type txMessenger struct {
+	*sdkrpc.HTTPMessenger
+	client   *Client
+	endpoint *endpoint
+	ctx      context.Context
+}
+
+// SendRPC sends a request, and returns the reply in the same form as the HTTPMessenger of the SDK
+func (m *txMessenger) SendRPC(meth string, params []interface{}) (sdkrpc.Reply, error) {
+	ctx, cancel := m.client.withTimeout(m.ctx)
+	defer cancel()
+	v, err := m.endpoint.call(ctx, meth, params)
+	if err != nil {
+		return nil, err
+	}
+	return sdkrpc.Reply{"result": v}, nil
+}
+
+// refreshHealth checks the health of the endpoints if it's time to, and makes the most preferred healthy one current.
+// An endpoint is unhealthy if it is unreachable or its latest block lags behind the highest one by more than maxBlockLag.
+// The endpoints are probed without the lock held, so it must be called without the lock held.
+func (c *Client) refreshHealth(ctx context.Context) {
+	c.mu.Lock()
+	if c.pinned > 0 || len(c.endpoints) == 1 || time.Since(c.checkedAt) <= healthCheckInterval {
+		c.mu.Unlock()
+		return
+	}
+	// checkedAt is updated first so that concurrent requests don't probe the endpoints again
+	c.checkedAt = time.Now()
+	endpoints, timeout := c.endpoints, c.opts.Timeout
+	c.mu.Unlock()
+
+	heights := make([]uint64, len(endpoints))
+	reachable := make([]bool, len(endpoints))
+	for i, e := range endpoints {
+		height, err := blockNumberOf(ctx, e, timeout)
+		if err != nil {
+			log.Printf("harmony: endpoint %s is unreachable: %v", e.url, err)
+			continue
+		}
+		heights[i], reachable[i] = height, true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var best uint64
+	for i, e := range endpoints {
+		e.healthy, e.height = reachable[i], heights[i]
+		if e.height > best {
+			best = e.height
+		}
+	}
+	c.observeHeight(best)
+	for _, e := range endpoints {
+		if e.healthy && best-e.height > c.opts.MaxBlockLag {
+			log.Printf("harmony: endpoint %s lags behind by %d blocks", e.url, best-e.height)
+			e.healthy = false
+		}
+	}
+	// an endpoint pinned while probing is kept
+	if c.pinned > 0 {
+		return
+	}
+	for i, e := range endpoints {
+		if e.healthy {
+			if i != c.current {
+				log.Printf("harmony: switch endpoint to %s", e.url)
+			}
+			c.current = i
+			return
+		}
+	}
+	log.Println("harmony: no healthy endpoint found")
+}
+
+// failover switches the current endpoint to the next healthy one after a request to the given endpoint failed
+func (c *Client) failover(e *endpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pinned > 0 || len(c.endpoints) == 1 || c.endpoints[c.current] != e {
+		return
+	}
+	e.healthy = false
+	next := (c.current + 1) % len(c.endpoints)
+	for i := 1; i < len(c.endpoints); i++ {
+		if j := (c.current + i) % len(c.endpoints); c.endpoints[j].healthy {
+			next = j
+			break
+		}
+	}
+	log.Printf("harmony: fail over from %s to %s", e.url, c.endpoints[next].url)
+	c.current = next
+}
+
+// DetectArchive checks whether each endpoint serves the state of blocks out of the pruning window.
+// The endpoints are probed without the lock held.
+// The client calls it before its first query out of the pruning window, so callers only need it to detect them again.
+func (c *Client) DetectArchive(ctx context.Context) {
+	c.mu.Lock()
+	endpoints, timeout, window := c.endpoints, c.opts.Timeout, c.opts.PruningWindow
+	c.mu.Unlock()
+
+	latests := make([]uint64, len(endpoints))
+	archives := make([]bool, len(endpoints))
+	reachable := make([]bool, len(endpoints))
+	for i, e := range endpoints {
+		latest, err := blockNumberOf(ctx, e, timeout)
+		if err != nil {
+			log.Printf("harmony: endpoint %s is unreachable: %v", e.url, err)
+			continue
+		}
+		latests[i], reachable[i] = latest, true
+		if latest <= window {
+			// the state of every block is still in the pruning window
+			archives[i] = true
+			continue
+		}
+		// non-archive nodes fail to serve the state of the first block
+		rctx, cancel := contextWithTimeout(ctx, timeout)
+		_, err = e.call(rctx, MethodGetBalance, []interface{}{common.Address{}, hexutil.EncodeUint64(1)})
+		cancel()
+		archives[i] = err == nil
+		if !archives[i] {
+			log.Printf("harmony: endpoint %s is not an archive node", e.url)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, e := range endpoints {
+		if reachable[i] {
+			c.observeHeight(latests[i])
+			e.archive = archives[i]
+		}
+	}
+	c.archiveDetected = true
+}
+
+// detectArchiveIfNeeded detects the archive endpoints before the first query out of the pruning window,
+// so that the endpoints aren't probed by invocations which only query recent state
+func (c *Client) detectArchiveIfNeeded(ctx context.Context, height uint64) {
+	c.mu.Lock()
+	detected := c.archiveDetected
+	c.mu.Unlock()
+	if height == 0 || detected {
+		return
+	}
+	c.detectMu.Lock()
+	defer c.detectMu.Unlock()
+	c.mu.Lock()
+	detected, latest, e, timeout := c.archiveDetected, c.latest, c.endpoints[c.current], c.opts.Timeout
+	c.mu.Unlock()
+	if detected {
+		return
+	}
+	if latest == 0 {
+		// the health of a single endpoint isn't checked, so the latest height may be unknown yet
+		if latest, err := blockNumberOf(ctx, e, timeout); err == nil {
+			c.mu.Lock()
+			c.observeHeight(latest)
+			c.mu.Unlock()
+		}
+	}
+	c.mu.Lock()
+	historical := c.isHistorical(height)
+	c.mu.Unlock()
+	if historical {
+		c.DetectArchive(ctx)
+	}
+}
+
+// blockNumberOf returns the latest block number of the given endpoint.
+// It doesn't touch the state of the client, so it can be called without the lock held.
+func blockNumberOf(ctx context.Context, e *endpoint, timeout time.Duration) (uint64, error) {
+	ctx, cancel := contextWithTimeout(ctx, timeout)
+	defer cancel()
+	val, err := e.call(ctx, v1.Method.BlockNumber, nil)
+	if err != nil {
+		return 0, err
+	}
+	bns, ok := val.(string)
+	if !ok {
+		return 0, errors.New("could not get the latest block number")
+	}
+	return hexutil.DecodeUint64(bns)
+}
+
+// observeHeight records the given block number if it's the highest one seen so far.
+// It must be called with the lock held.
+func (c *Client) observeHeight(height uint64) {
+	if height > c.latest {
+		c.latest = height
+	}
+}
+
+// isHistorical returns true if the state at the given height is out of the pruning window.
+// It must be called with the lock held.
+func (c *Client) isHistorical(height uint64) bool {
+	return height > 0 && c.latest > c.opts.PruningWindow && height < c.latest-c.opts.PruningWindow
+}
+
+// CanServe returns true if an endpoint is expected to serve the state at the given height
+func (c *Client) CanServe(height uint64) bool {
+	c.detectArchiveIfNeeded(context.Background(), height)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.isHistorical(height) {
+		return true
+	}
+	for _, e := range c.endpoints {
+		if e.archive {
+			return true
+		}
+	}
+	return false
+}
+
+// endpointFor returns the endpoint to query the state at the given height.
+// Queries out of the pruning window are routed to an archive endpoint.
+// If height is 0, it returns the current endpoint.
+func (c *Client) endpointFor(ctx context.Context, height uint64) (*endpoint, error) {
+	c.refreshHealth(ctx)
+	c.detectArchiveIfNeeded(ctx, height)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := c.endpoints[c.current]
+	if !c.isHistorical(height) || e.archive {
+		return e, nil
+	}
+	if c.pinned == 0 {
+		for _, a := range c.endpoints {
+			if a.healthy && a.archive {
+				return a, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("height %d is out of the pruning window of %d blocks from %d, and no archive endpoint is available",
+		height, c.opts.PruningWindow, c.latest)
+}
+
+// do calls fn with the endpoint to query the state at the given height, and retries it with backoff on failure
+// until ctx is done. If height is 0, the request doesn't depend on the state of a specific block.
+// An RPCError is returned as it is, because another endpoint or another attempt would return the same error.
+func (c *Client) do(ctx context.Context, height uint64, fn func(e *endpoint) error) error {
+	if _, err := c.endpointFor(ctx, height); err != nil {
+		return err
+	}
+	return retry.Do(func() error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		e, err := c.endpointFor(ctx, height)
+		if err != nil {
+			return err
+		}
+		if err := fn(e); err != nil {
+			// the endpoint is not to blame if the caller gave up or the request itself failed
+			if ctx.Err() == nil && !isRPCError(err) {
+				c.failover(e)
+			}
+			return err
+		}
+		return nil
+	}, retry.Attempts(c.opts.MaxRetries+1), retry.Delay(rpcRetryDelay), retry.LastErrorOnly(true), retry.Context(ctx),
+		retry.RetryIf(func(err error) bool { return !isRPCError(err) }))
+}
+
+// RPCError is an error object in a JSON-RPC response, such as a reverted call or a missing trie node.
+// Unlike transport, HTTP status and timeout errors, it's deterministic for the request.
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	if len(e.Data) > 0 {
+		return fmt.Sprintf("rpc error %d: %s: %s", e.Code, e.Message, e.Data)
+	}
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+func isRPCError(err error) bool {
+	var rpcErr *RPCError
+	return errors.As(err, &rpcErr)
+}
+
+// failoverTransport sends HTTP requests of an Ethereum compatible client to the current endpoint of the client
+type failoverTransport struct {
+	client *Client
+	base   http.RoundTripper
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	var res *http.Response
+	ctx := req.Context()
+	err := t.client.do(ctx, requestHeight(body), func(e *endpoint) error {
+		u, err := url.Parse(e.url)
+		if err != nil {
+			return err
+		}
+		rctx, cancel := t.client.withTimeout(ctx)
+		r := req.Clone(rctx)
+		r.URL, r.Host = u, u.Host
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		resp, err := t.base.RoundTrip(r)
+		if err != nil {
+			cancel()
+			return err
+		}
+		if resp.StatusCode >= http.StatusInternalServerError {
+			resp.Body.Close()
+			cancel()
+			return fmt.Errorf("%s returned %s", e.url, resp.Status)
+		}
+		// the timeout covers reading the body as well
+		resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+		res = resp
+		return nil
+	})
+	return res, err
+}
+
+// cancelOnClose cancels the context of a request when its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
 // BlockNumber returns the most recent block number
 func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
 	invalidRes := uint64(0)
 
-	val, err := c.sendRPC(v1.Method.BlockNumber, nil)
+	val, err := c.sendRPC(ctx, v1.Method.BlockNumber, nil)
 	if err != nil {
 		return invalidRes, err
 	}
@@ -55,7 +552,14 @@ func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
 	if !ok {
 		return invalidRes, errors.New("could not get the latest block number")
 	}
-	return hexutil.DecodeUint64(bns)
+	height, err := hexutil.DecodeUint64(bns)
+	if err != nil {
+		return invalidRes, err
+	}
+	c.mu.Lock()
+	c.observeHeight(height)
+	c.mu.Unlock()
+	return height, nil
 }
 
 // FullHeader returns the harmony full header for the given height.
@@ -67,7 +571,7 @@ func (c *Client) FullHeader(ctx context.Context, height uint64) (*v2.BlockHeader
 	} else {
 		heightArg = "latest"
 	}
-	val, err := c.sendRPC(MethodGetFullHeader, []interface{}{heightArg})
+	val, err := c.sendRPC(ctx, MethodGetFullHeader, []interface{}{heightArg})
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +590,7 @@ func (c *Client) FullHeader(ctx context.Context, height uint64) (*v2.BlockHeader
 // EpochLastBlockNumber returns the last block number of the given epoch.
 // Note that it also returns the block number for a future epoch.
 func (c *Client) EpochLastBlockNumber(ctx context.Context, epoch uint64) (uint64, error) {
-	val, err := c.sendRPC(MethodEpochLastBlock, []interface{}{epoch})
+	val, err := c.sendRPC(ctx, MethodEpochLastBlock, []interface{}{epoch})
 	if err != nil {
 		return 0, err
 	}
@@ -98,6 +602,20 @@ func (c *Client) EpochLastBlockNumber(ctx context.Context, epoch uint64) (uint64
 	return bn.Uint64(), nil
 }
 
+// ChainID returns the Harmony chain ID of the node, which is not the Ethereum compatible one
+func (c *Client) ChainID(ctx context.Context) (uint64, error) {
+	val, err := c.sendRPC(ctx, MethodChainID, nil)
+	if err != nil {
+		return 0, err
+	}
+	num, ok := val.(float64)
+	if !ok {
+		return 0, errors.New("could not get the chain id")
+	}
+	id, _ := big.NewFloat(num).Int(nil)
+	return id.Uint64(), nil
+}
+
 // if height <= 0, get the latest result
 func (chain *Chain) CallOpts(ctx context.Context, height int64) *bind.CallOpts {
 	account, err := chain.getAccount()
@@ -116,14 +634,107 @@ func (chain *Chain) CallOpts(ctx context.Context, height int64) *bind.CallOpts {
 	return opts
 }
 
-func (c *Client) sendRPC(meth string, params []interface{}) (interface{}, error) {
-	rep, err := c.messenger.SendRPC(meth, params)
+func (c *Client) sendRPC(ctx context.Context, meth string, params []interface{}) (interface{}, error) {
+	return c.sendRPCAt(ctx, 0, meth, params)
+}
+
+// sendRPCAt sends a request which queries the state at the given height
+func (c *Client) sendRPCAt(ctx context.Context, height uint64, meth string, params []interface{}) (interface{}, error) {
+	var val interface{}
+	err := c.do(ctx, height, func(e *endpoint) error {
+		rctx, cancel := c.withTimeout(ctx)
+		defer cancel()
+		v, err := e.call(rctx, meth, params)
+		if err != nil {
+			return err
+		}
+		val = v
+		return nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("rpc %s with params %v failed: %w", meth, params, err)
 	}
-	val, ok := rep["result"]
+	return val, nil
+}
+
+// call sends a JSON-RPC request to the endpoint, and returns the result
+func (e *endpoint) call(ctx context.Context, meth string, params []interface{}) (interface{}, error) {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  meth,
+		"params":  params,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", e.url, res.Status)
+	}
+	var rep map[string]json.RawMessage
+	if err := json.NewDecoder(res.Body).Decode(&rep); err != nil {
+		return nil, err
+	}
+	if raw, ok := rep["error"]; ok && string(raw) != "null" {
+		rpcErr := new(RPCError)
+		if err := json.Unmarshal(raw, rpcErr); err != nil {
+			return nil, fmt.Errorf("invalid error object %s: %w", raw, err)
+		}
+		return nil, rpcErr
+	}
+	raw, ok := rep["result"]
 	if !ok {
-		return nil, fmt.Errorf("rpc %s with params %v returns invalid response", meth, params)
+		return nil, errors.New("invalid response")
 	}
-	return val, nil
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// requestHeight returns the block number of a state query in a JSON-RPC request of an Ethereum compatible client.
+// It returns 0 for other requests and queries at the latest block.
+func requestHeight(body []byte) uint64 {
+	var req struct {
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return 0
+	}
+	var i int
+	switch req.Method {
+	case "eth_call", "eth_getBalance", "eth_getCode", "eth_getTransactionCount":
+		i = 1
+	case "eth_getStorageAt", "eth_getProof":
+		i = 2
+	default:
+		return 0
+	}
+	if i >= len(req.Params) {
+		return 0
+	}
+	var arg string
+	if err := json.Unmarshal(req.Params[i], &arg); err != nil {
+		return 0
+	}
+	height, err := hexutil.DecodeUint64(arg)
+	if err != nil {
+		return 0
+	}
+	return height
 }
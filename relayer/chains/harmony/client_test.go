@@ -0,0 +1,236 @@
+package harmony
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/harmony/fakenode"
+)
+
+func TestClientFailover(t *testing.T) {
+	down := fakenode.New(0, testBlocksPerEpoch)
+	down.Close()
+	up := fakenode.New(0, testBlocksPerEpoch)
+	defer up.Close()
+	mineBlocks(up, 3)
+
+	client := NewHarmonyClientWithFailover([]string{down.URL(), up.URL()}, DefaultClientOptions())
+	height, err := client.BlockNumber(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != 3 {
+		t.Fatalf("unexpected height: %v", height)
+	}
+
+	// contract calls fail over as well
+	contract := common.HexToAddress("0x01")
+	up.HandleCall(contract, func(input []byte) ([]byte, error) {
+		return input, nil
+	})
+	ethClient, err := client.ETHClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := ethClient.CallContract(context.Background(), ethereum.CallMsg{To: &contract, Data: []byte{1, 2, 3, 4}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, []byte{1, 2, 3, 4}) {
+		t.Fatalf("unexpected output: %x", out)
+	}
+}
+
+func TestClientSkipsLaggingEndpoint(t *testing.T) {
+	lagging := fakenode.New(0, testBlocksPerEpoch)
+	defer lagging.Close()
+	latest := fakenode.New(0, testBlocksPerEpoch)
+	defer latest.Close()
+	mineBlocks(lagging, 2)
+	mineBlocks(latest, 20)
+
+	opts := DefaultClientOptions()
+	opts.MaxBlockLag = 5
+	client := NewHarmonyClientWithFailover([]string{lagging.URL(), latest.URL()}, opts)
+	height, err := client.BlockNumber(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != 20 {
+		t.Fatalf("the lagging endpoint must be skipped, but got height %v", height)
+	}
+}
+
+func TestClientPin(t *testing.T) {
+	primary := fakenode.New(0, testBlocksPerEpoch)
+	secondary := fakenode.New(0, testBlocksPerEpoch)
+	defer secondary.Close()
+
+	opts := DefaultClientOptions()
+	opts.MaxRetries = 1
+	client := NewHarmonyClientWithFailover([]string{primary.URL(), secondary.URL()}, opts)
+	unpin := client.Pin(0)
+	primary.Close()
+	if _, err := client.BlockNumber(context.Background()); err == nil {
+		t.Fatal("a pinned client must not fail over")
+	}
+	unpin()
+	if _, err := client.BlockNumber(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientRoutesHistoricalQueriesToArchive(t *testing.T) {
+	const window = 4
+	pruned := fakenode.New(0, testBlocksPerEpoch)
+	defer pruned.Close()
+	archive := fakenode.New(0, testBlocksPerEpoch)
+	defer archive.Close()
+	pruned.SetPruningWindow(window)
+	mineBlocks(pruned, 20)
+	mineBlocks(archive, 20)
+
+	opts := DefaultClientOptions()
+	opts.PruningWindow = window
+	client := NewHarmonyClientWithFailover([]string{pruned.URL(), archive.URL()}, opts)
+
+	// the archive endpoints are detected on the first query out of the pruning window
+	for _, height := range []int64{18, 2} {
+		if _, err := client.GetETHProof(context.Background(), testIBCHostAddress, nil, big.NewInt(height)); err != nil {
+			t.Fatalf("height %d: %v", height, err)
+		}
+		if detected := client.archiveDetected; detected != (height == 2) {
+			t.Fatalf("height %d: unexpected archive detection: %v", height, detected)
+		}
+	}
+	if client.endpoints[0].archive || !client.endpoints[1].archive {
+		t.Fatalf("unexpected archive detection: %v, %v", client.endpoints[0].archive, client.endpoints[1].archive)
+	}
+	// the recent state is still served by the preferred endpoint
+	if client.endpoints[client.current] != client.endpoints[0] {
+		t.Fatal("the current endpoint must not be switched")
+	}
+
+	// a pinned query at a historical height is served by the archive endpoint
+	unpin := client.Pin(2)
+	defer unpin()
+	ethClient, err := client.ETHClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ethClient.BalanceAt(context.Background(), common.Address{}, big.NewInt(2)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientWithoutArchive(t *testing.T) {
+	const window = 4
+	node := fakenode.New(0, testBlocksPerEpoch)
+	defer node.Close()
+	node.SetPruningWindow(window)
+	mineBlocks(node, 20)
+
+	opts := DefaultClientOptions()
+	opts.PruningWindow = window
+	client := NewHarmonyClientWithFailover([]string{node.URL()}, opts)
+
+	// the latest height of a single endpoint is queried to tell whether the height is out of the pruning window
+	if client.CanServe(2) {
+		t.Fatal("the state out of the pruning window can't be served")
+	}
+	if !client.CanServe(18) {
+		t.Fatal("the state in the pruning window must be served")
+	}
+	_, err := client.GetETHProof(context.Background(), testIBCHostAddress, nil, big.NewInt(2))
+	if err == nil || !strings.Contains(err.Error(), "pruning window") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	hung := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer hung.Close()
+
+	opts := DefaultClientOptions()
+	opts.MaxRetries = 0
+	opts.Timeout = 100 * time.Millisecond
+	client := NewHarmonyClientWithFailover([]string{hung.URL}, opts)
+	start := time.Now()
+	if _, err := client.BlockNumber(context.Background()); err == nil {
+		t.Fatal("a request to a hung node must time out")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("the request took too long: %v", elapsed)
+	}
+
+	// the context of the caller is honored as well
+	client.SetTimeout(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := client.BlockNumber(ctx); err == nil {
+		t.Fatal("a canceled request must fail")
+	}
+	ethClient, err := client.ETHClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ethClient.HeaderByNumber(ctx, nil); err == nil {
+		t.Fatal("a canceled request must fail")
+	}
+
+	// requests of transactions through the Harmony SDK time out as well
+	client.SetTimeout(100 * time.Millisecond)
+	start = time.Now()
+	if _, err := client.messenger(context.Background()).SendRPC(MethodGetBalance, []interface{}{common.Address{}, "latest"}); err == nil {
+		t.Fatal("a request to a hung node must time out")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("the request took too long: %v", elapsed)
+	}
+}
+
+func TestClientDoesNotFailOverOnRPCError(t *testing.T) {
+	primary := fakenode.New(0, testBlocksPerEpoch)
+	defer primary.Close()
+	secondary := fakenode.New(0, testBlocksPerEpoch)
+	defer secondary.Close()
+	mineBlocks(primary, 3)
+	mineBlocks(secondary, 3)
+
+	client := NewHarmonyClientWithFailover([]string{primary.URL(), secondary.URL()}, DefaultClientOptions())
+	_, err := client.FullHeader(context.Background(), 100)
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || !strings.Contains(rpcErr.Message, "not found") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if url := client.endpoints[client.current].url; url != primary.URL() {
+		t.Fatalf("the client must stay on the primary endpoint, but switched to %s", url)
+	}
+
+	// the same applies to the requests of the Ethereum compatible client
+	contract := common.HexToAddress("0x01")
+	primary.HandleCall(contract, func(input []byte) ([]byte, error) {
+		return nil, errors.New("execution reverted")
+	})
+	ethClient, err := client.ETHClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ethClient.CallContract(context.Background(), ethereum.CallMsg{To: &contract}, nil); err == nil {
+		t.Fatal("a reverted call must fail")
+	}
+	if url := client.endpoints[client.current].url; url != primary.URL() {
+		t.Fatalf("the client must stay on the primary endpoint, but switched to %s", url)
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	retry "github.com/avast/retry-go"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/modules/apps/transfer/types"
+	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
+	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/harmony"
+	"github.com/mapdev33/yui-relayer/core"
+)
+
+const (
+	closeConfirmAttempts = 20
+	closeConfirmDelay    = 3 * time.Second
+)
+
+// closeChannel closes the channel of the path on src, and then confirms the closing on dst.
+// The transfer module of ibc-go rejects closing its channels, so they can only be closed from Harmony.
+func closeChannel(src, dst *core.ProvableChain) error {
+	if _, ok := src.ChainI.(*harmony.Chain); !ok && src.Path().PortID == transfertypes.PortID {
+		return fmt.Errorf("the transfer module on %s doesn't allow closing channel %s, so close it from %s instead",
+			src.ChainID(), src.Path().ChannelID, dst.ChainID())
+	}
+	srcChan, err := src.QueryChannel(0)
+	if err != nil {
+		return err
+	}
+	switch srcChan.Channel.State {
+	case chantypes.OPEN:
+		signer, err := src.GetAddress()
+		if err != nil {
+			return err
+		}
+		tx := core.RelayMsgs{
+			Src: []sdk.Msg{chantypes.NewMsgChannelCloseInit(src.Path().PortID, src.Path().ChannelID, signer.String())},
+			Dst: []sdk.Msg{},
+		}
+		if tx.Send(src, dst); !tx.Succeeded {
+			return fmt.Errorf("failed to close channel %s on %s", src.Path().ChannelID, src.ChainID())
+		}
+		log.Printf("★ Channel closed: [%s]chan(%s)", src.ChainID(), src.Path().ChannelID)
+	case chantypes.CLOSED:
+		log.Printf("channel %s on %s is already closed", src.Path().ChannelID, src.ChainID())
+	default:
+		return fmt.Errorf("channel %s on %s can't be closed in state %s", src.Path().ChannelID, src.ChainID(), srcChan.Channel.State)
+	}
+
+	dstChan, err := dst.QueryChannel(0)
+	if err != nil {
+		return err
+	}
+	if dstChan.Channel.State == chantypes.CLOSED {
+		log.Printf("channel %s on %s is already closed", dst.Path().ChannelID, dst.ChainID())
+		return nil
+	}
+
+	// wait until a header in which the channel is closed becomes available to the client on dst
+	var (
+		header          core.HeaderI
+		queryableHeight int64
+		chanRes         *chantypes.QueryChannelResponse
+	)
+	if err := retry.Do(func() error {
+		header, _, queryableHeight, err = src.UpdateLightWithHeader()
+		if err != nil {
+			return err
+		}
+		chanRes, err = src.QueryChannelWithProof(queryableHeight)
+		if err != nil {
+			return err
+		}
+		if chanRes.Channel.State != chantypes.CLOSED {
+			return fmt.Errorf("channel %s on %s is not closed at height %d yet", src.Path().ChannelID, src.ChainID(), queryableHeight)
+		}
+		return nil
+	}, retry.Attempts(closeConfirmAttempts), retry.Delay(closeConfirmDelay), retry.LastErrorOnly(true)); err != nil {
+		return err
+	}
+
+	header, err = src.SetupHeader(dst, header)
+	if err != nil {
+		return err
+	}
+	signer, err := dst.GetAddress()
+	if err != nil {
+		return err
+	}
+	tx := core.RelayMsgs{
+		Src: []sdk.Msg{},
+		Dst: []sdk.Msg{
+			dst.Path().UpdateClient(header, signer),
+			chantypes.NewMsgChannelCloseConfirm(dst.Path().PortID, dst.Path().ChannelID, chanRes.Proof, chanRes.ProofHeight, signer.String()),
+		},
+	}
+	if tx.Send(src, dst); !tx.Succeeded {
+		return fmt.Errorf("failed to confirm closing channel %s on %s", dst.Path().ChannelID, dst.ChainID())
+	}
+	log.Printf("★ Channel closed: [%s]chan(%s)", dst.ChainID(), dst.Path().ChannelID)
+	return nil
+}
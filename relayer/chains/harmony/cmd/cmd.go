@@ -15,6 +15,8 @@ func HarmonyCmd(m codec.Codec, ctx *config.Context) *cobra.Command {
 	cmd.AddCommand(
 		queryCmd(ctx),
 		txCmd(ctx),
+		deployCmd(m, ctx),
+		configCmd(m, ctx),
 	)
 
 	return cmd
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/harmony"
+	"github.com/mapdev33/yui-relayer/config"
+	"github.com/mapdev33/yui-relayer/core"
+	"github.com/spf13/cobra"
+)
+
+func configCmd(m codec.Codec, ctx *config.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "manage Harmony chain configs",
+	}
+
+	cmd.AddCommand(
+		generateChainConfigCmd(m),
+		validateChainConfigCmd(ctx),
+	)
+
+	return cmd
+}
+
+// generateChainConfigCmd prints a chain config with the addresses of deployed contracts
+func generateChainConfigCmd(m codec.Codec) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "generate",
+		Short: "generate the config of a Harmony chain",
+		Long: "Generate the config of a Harmony chain with the addresses of the contracts, which are read from" +
+			" truffle build artifacts of a network or a deployment JSON such as {\"ibc_host\": \"0x...\", ...}." +
+			" The deployment height of IBCHost is taken from the flag or the deployment JSON, or found from the receipt of the deployment" +
+			" transaction in the truffle artifacts, and is required unless the chain is localnet." +
+			" On shard 0, the beacon endpoint and key default to the shard ones",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			var (
+				chainConfig  harmony.ChainConfig
+				proverConfig harmony.ProverConfig
+				err          error
+			)
+			if chainConfig.ChainId, err = flags.GetString(flagChainID); err != nil {
+				return err
+			}
+			if chainConfig.HarmonyChainId, err = flags.GetString(flagHarmonyChainID); err != nil {
+				return err
+			}
+			if _, err := chainConfig.ChainID(); err != nil {
+				return fmt.Errorf("invalid %s: %w", flagHarmonyChainID, err)
+			}
+			if chainConfig.ShardId, err = flags.GetUint32(flagShardID); err != nil {
+				return err
+			}
+			if chainConfig.ShardRpcAddr, err = flags.GetString(flagShardRPCAddr); err != nil {
+				return err
+			}
+			if chainConfig.BeaconRpcAddr, err = flags.GetString(flagBeaconRPCAddr); err != nil {
+				return err
+			}
+			if chainConfig.ShardPrivateKey, err = flags.GetString(flagShardPrivateKey); err != nil {
+				return err
+			}
+			if chainConfig.BeaconPrivateKey, err = flags.GetString(flagBeaconPrivateKey); err != nil {
+				return err
+			}
+			if chainConfig.ShardId == 0 {
+				if chainConfig.BeaconRpcAddr == "" {
+					chainConfig.BeaconRpcAddr = chainConfig.ShardRpcAddr
+				}
+				if chainConfig.BeaconPrivateKey == "" {
+					chainConfig.BeaconPrivateKey = chainConfig.ShardPrivateKey
+				}
+			} else if chainConfig.BeaconRpcAddr == "" {
+				return fmt.Errorf("--%s is required unless --%s is 0", flagBeaconRPCAddr, flagShardID)
+			}
+			if chainConfig.GasLimit, err = flags.GetUint64(flagGasLimit); err != nil {
+				return err
+			}
+			if chainConfig.GasPrice, err = flags.GetInt64(flagGasPrice); err != nil {
+				return err
+			}
+			if proverConfig.TrustingPeriod, err = flags.GetString(flagTrustingPeriod); err != nil {
+				return err
+			}
+			if err := proverConfig.Validate(chainConfig.ShardId); err != nil {
+				return err
+			}
+
+			deployment, err := loadDeployment(cmd)
+			if err != nil {
+				return err
+			}
+			if height, err := flags.GetUint64(flagDeploymentHeight); err != nil {
+				return err
+			} else if height != 0 {
+				deployment.IBCHostDeploymentHeight = height
+			}
+			if deployment.IBCHostDeploymentHeight == 0 && deployment.IBCHostTxHash != nil {
+				ethClient, err := chainConfig.NewShardClient().ETHClient()
+				if err != nil {
+					return err
+				}
+				if err := deployment.ResolveDeploymentHeight(context.Background(), ethClient); err != nil {
+					return err
+				}
+			}
+			deployment.Apply(&chainConfig)
+			if _, err := chainConfig.LogsStartHeight(); err != nil {
+				return fmt.Errorf("%w: give --%s", err, flagDeploymentHeight)
+			}
+
+			output, err := flags.GetString(flagOutput)
+			if err != nil {
+				return err
+			}
+			return writeChainConfig(m, output, &chainConfig, &proverConfig)
+		},
+	}
+	c.Flags().String(flagChainID, "ibc1", "chain ID of the chain in the relayer")
+	c.Flags().String(flagHarmonyChainID, "localnet", "Harmony chain ID such as mainnet, testnet and localnet")
+	c.Flags().Uint32(flagShardID, 0, "shard ID")
+	c.Flags().String(flagShardRPCAddr, "", "RPC endpoint of the shard")
+	c.Flags().String(flagBeaconRPCAddr, "", "RPC endpoint of the beacon shard (defaults to the shard endpoint on shard 0)")
+	c.Flags().String(flagShardPrivateKey, "", "hex encoded private key of the relayer on the shard")
+	c.Flags().String(flagBeaconPrivateKey, "", "hex encoded private key of the relayer on the beacon shard (defaults to the shard key on shard 0)")
+	c.Flags().String(flagBuildDir, "", "truffle build directory to read the addresses of the contracts from")
+	c.Flags().String(flagNetworkID, "2", "network ID of the truffle deployment")
+	c.Flags().String(flagDeployment, "", "deployment JSON to read the addresses of the contracts from")
+	c.Flags().Uint64(flagDeploymentHeight, 0, "block at which IBCHost was deployed (defaults to the one in the deployment)")
+	c.Flags().Uint64(flagGasLimit, 5000000, "gas limit of a transaction")
+	c.Flags().Int64(flagGasPrice, 100, "gas price in gwei")
+	c.Flags().String(flagTrustingPeriod, "120h", "trusting period of the Harmony client on the counterparty")
+	c.Flags().String(flagOutput, "", "file to write the config to (defaults to stdout)")
+	_ = c.MarkFlagRequired(flagShardRPCAddr)
+	_ = c.MarkFlagRequired(flagShardPrivateKey)
+	return c
+}
+
+// writeChainConfig writes the chain and prover configs to the output file, or prints them if output is empty
+func writeChainConfig(m codec.Codec, output string, chainConfig *harmony.ChainConfig, proverConfig *harmony.ProverConfig) error {
+	cpc, err := core.NewChainProverConfig(m, chainConfig, proverConfig)
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(cpc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		fmt.Println(string(out))
+		return nil
+	}
+	return ioutil.WriteFile(output, out, 0644)
+}
+
+// storeChainConfig replaces the config of the chain in the relayer config with the given one,
+// and writes the relayer config to the relayer home
+func storeChainConfig(cmd *cobra.Command, ctx *config.Context, m codec.Codec, chainConfig *harmony.ChainConfig, proverConfig *harmony.ProverConfig) error {
+	cpc, err := core.NewChainProverConfig(m, chainConfig, proverConfig)
+	if err != nil {
+		return err
+	}
+	found := false
+	for i, c := range ctx.Config.Chains {
+		var chain struct {
+			ChainID string `json:"chain_id"`
+		}
+		if err := json.Unmarshal(c.Chain, &chain); err != nil {
+			return err
+		}
+		if chain.ChainID == chainConfig.ChainId {
+			ctx.Config.Chains[i] = *cpc
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("chain %s not found in the relayer config", chainConfig.ChainId)
+	}
+	home, err := cmd.Flags().GetString(flags.FlagHome)
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(ctx.Config, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(home, "config", "config.yaml"), out, 0600)
+}
+
+// loadDeployment loads the addresses of the contracts from either a truffle build directory or a deployment JSON
+func loadDeployment(cmd *cobra.Command) (*harmony.Deployment, error) {
+	buildDir, err := cmd.Flags().GetString(flagBuildDir)
+	if err != nil {
+		return nil, err
+	}
+	deployment, err := cmd.Flags().GetString(flagDeployment)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case buildDir != "" && deployment != "":
+		return nil, fmt.Errorf("--%s and --%s can't be given at the same time", flagBuildDir, flagDeployment)
+	case buildDir != "":
+		networkID, err := cmd.Flags().GetString(flagNetworkID)
+		if err != nil {
+			return nil, err
+		}
+		return harmony.LoadDeploymentFromArtifacts(buildDir, networkID)
+	case deployment != "":
+		return harmony.LoadDeployment(deployment)
+	default:
+		return nil, fmt.Errorf("either --%s or --%s is required", flagBuildDir, flagDeployment)
+	}
+}
+
+// validateChainConfigCmd checks the chain config against the chain before any tx reverts because of it
+func validateChainConfigCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "validate [chain-id]",
+		Short: "validate the config of a Harmony chain against the chain",
+		Long: "Check that each configured contract address has code, that IBCHost's IBC module is IBCHandler," +
+			" that the transfer port is bound to ICS20TransferBank, that the 07-tendermint client type is registered," +
+			" that ICS20TransferBank is an operator of ICS20Bank, that harmony_chain_id matches the node, and that the relayer key is funded",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := ctx.Config.GetChain(args[0])
+			if err != nil {
+				return err
+			}
+			chain, ok := c.ChainI.(*harmony.Chain)
+			if !ok {
+				return errors.New("invalid chain-id")
+			}
+			failed := 0
+			for _, check := range chain.ValidateConfig(context.Background()) {
+				if check.Err != nil {
+					failed++
+					fmt.Printf("FAIL %s: %v\n", check.Name, check.Err)
+				} else {
+					fmt.Printf("ok   %s\n", check.Name)
+				}
+			}
+			if failed > 0 {
+				return fmt.Errorf("%d checks failed", failed)
+			}
+			return nil
+		},
+	}
+	return c
+}
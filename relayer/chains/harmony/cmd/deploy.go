@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/harmony"
+	"github.com/mapdev33/yui-relayer/config"
+	"github.com/spf13/cobra"
+)
+
+// deployCmd deploys the IBC contracts with the relayer key, and stores the chain config with their addresses in the relayer config
+func deployCmd(m codec.Codec, ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "deploy [chain-id]",
+		Short: "deploy and wire up the IBC contracts",
+		Long: "Deploy IBCHost, IBCHandler, TendermintLightClient, SimpleToken, ICS20Bank and ICS20TransferBank with the relayer key," +
+			" then set the IBC module of IBCHost, bind the transfer port, register the Tendermint light client and set the operator of ICS20Bank." +
+			" The Go bindings don't contain the bytecode, so it is taken from truffle build artifacts, which requires compiling the contracts" +
+			" with Node.js and truffle (npm run compile in contract) beforehand." +
+			" The chain config in the relayer config is updated with the deployed addresses and the deployment height of IBCHost," +
+			" and is also written to the output if given",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := ctx.Config.GetChain(args[0])
+			if err != nil {
+				return err
+			}
+			chain, ok := c.ChainI.(*harmony.Chain)
+			if !ok {
+				return errors.New("invalid chain-id")
+			}
+			prover, ok := c.ProverI.(*harmony.Prover)
+			if !ok {
+				return errors.New("invalid prover")
+			}
+			dir, err := cmd.Flags().GetString(flagArtifacts)
+			if err != nil {
+				return err
+			}
+			output, err := cmd.Flags().GetString(flagOutput)
+			if err != nil {
+				return err
+			}
+			artifacts, err := harmony.LoadContractArtifacts(dir)
+			if err != nil {
+				return err
+			}
+			deployer, err := chain.NewDeployer(context.Background(), artifacts)
+			if err != nil {
+				return err
+			}
+			deployment, err := deployer.Deploy(context.Background())
+			if err != nil {
+				return err
+			}
+			bz, err := json.Marshal(deployment)
+			if err != nil {
+				return err
+			}
+			log.Printf("deployed: %s", bz)
+
+			chainConfig, proverConfig := chain.Config(), prover.Config()
+			deployment.Apply(&chainConfig)
+			if err := storeChainConfig(cmd, ctx, m, &chainConfig, &proverConfig); err != nil {
+				return err
+			}
+			log.Printf("updated the chain config of %s in the relayer config", args[0])
+			if output == "" {
+				return nil
+			}
+			return writeChainConfig(m, output, &chainConfig, &proverConfig)
+		},
+	}
+	c.Flags().String(flagArtifacts, "./contract/build/contracts", "directory of the truffle build artifacts")
+	c.Flags().String(flagOutput, "", "file to write the chain config with the deployed addresses to as well")
+	return c
+}
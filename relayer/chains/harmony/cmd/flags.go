@@ -11,10 +11,34 @@ const (
 	flagAmount              = "amount"
 	flagDenom               = "denom"
 	flagBankId              = "bank-id"
+	flagHeight              = "height"
+	flagProve               = "prove"
+	flagArtifacts           = "artifacts"
+	flagOutput              = "output"
+	flagChainID             = "chain-id"
+	flagHarmonyChainID      = "harmony-chain-id"
+	flagShardID             = "shard-id"
+	flagShardRPCAddr        = "shard-rpc-addr"
+	flagBeaconRPCAddr       = "beacon-rpc-addr"
+	flagShardPrivateKey     = "shard-private-key"
+	flagBeaconPrivateKey    = "beacon-private-key"
+	flagBuildDir            = "build-dir"
+	flagNetworkID           = "network-id"
+	flagDeployment          = "deployment"
+	flagDeploymentHeight    = "ibc-host-deployment-height"
+	flagGasLimit            = "gas-limit"
+	flagGasPrice            = "gas-price"
+	flagTrustingPeriod      = "trusting-period"
 	flagTimeoutHeightOffset = "timeout-height-offset"
 	flagTimeoutTimeOffset   = "timeout-time-offset"
+	flagToken               = "token"
 )
 
+// defaultTimeoutHeightOffset is the timeout of a transfer in blocks of the counterparty
+const defaultTimeoutHeightOffset = 1000
+
+const amountUsage = "amount in the unit of the decimals of the denom in denom_units, such as 1.5, or in the smallest unit if they aren't configured"
+
 func ownerFlags(cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().String(flagOwner, "", "owner hex address string")
 	_ = cmd.MarkFlagRequired(flagOwner)
@@ -24,15 +48,46 @@ func ownerFlags(cmd *cobra.Command) *cobra.Command {
 func sendTransferFlags(cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().String(flagDenom, "", "denom")
 	cmd.Flags().String(flagReceiver, "", "receiver address")
-	cmd.Flags().Uint64(flagAmount, 0, "amount")
+	cmd.Flags().String(flagAmount, "", amountUsage)
 	_ = cmd.MarkFlagRequired(flagDenom)
 	_ = cmd.MarkFlagRequired(flagReceiver)
 	_ = cmd.MarkFlagRequired(flagAmount)
 	return cmd
 }
 
+// bankTransferFlags are the flags of moving an ERC20 token into or out of ICS20Bank
+func bankTransferFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().String(flagToken, "", "ERC20 token address (defaults to token_address)")
+	cmd.Flags().String(flagReceiver, "", "receiver address (defaults to the relayer address)")
+	cmd.Flags().String(flagAmount, "", amountUsage)
+	_ = cmd.MarkFlagRequired(flagAmount)
+	return cmd
+}
+
+func tokenFlag(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().String(flagToken, "", "ERC20 token address (defaults to token_address)")
+	return cmd
+}
+
+// transferTimeoutFlags are the offsets of the timeout of a transfer from the latest block of the counterparty
+func transferTimeoutFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().Uint64(flagTimeoutHeightOffset, defaultTimeoutHeightOffset, "timeout height offset from the latest height of the counterparty")
+	cmd.Flags().Duration(flagTimeoutTimeOffset, 0, "timeout time offset, which is not supported as sendTransfer of ICS20TransferBank doesn't take a timeout timestamp")
+	return cmd
+}
+
 func bankIdFlags(cmd *cobra.Command) *cobra.Command {
 	cmd.Flags().String(flagBankId, "", "bank id")
 	_ = cmd.MarkFlagRequired(flagBankId)
 	return cmd
 }
+
+func heightFlag(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().Int64(flagHeight, 0, "height to query at (0 means the latest height)")
+	return cmd
+}
+
+func proveFlag(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().Bool(flagProve, false, "also print the storage proof and its slot key")
+	return cmd
+}
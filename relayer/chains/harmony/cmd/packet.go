@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
+	hmylctypes "github.com/datachainlab/ibc-harmony-client/modules/light-clients/harmony/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	proto "github.com/gogo/protobuf/proto"
+	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/harmony"
+	"github.com/mapdev33/yui-relayer/config"
+	"github.com/mapdev33/yui-relayer/core"
+	"github.com/spf13/cobra"
+)
+
+// packetQuery is the Harmony chain of a path and the height to query at
+type packetQuery struct {
+	chain  *harmony.Chain
+	prover core.ProverI
+	height int64
+}
+
+// newPacketQuery returns the query of the Harmony chain of the given path at the height of the flag
+func newPacketQuery(ctx *config.Context, cmd *cobra.Command, pathName string) (*packetQuery, error) {
+	chains, src, dst, err := ctx.Config.ChainsFromPath(pathName)
+	if err != nil {
+		return nil, err
+	}
+	var q packetQuery
+	for _, id := range []string{src, dst} {
+		if chain, ok := chains[id].ChainI.(*harmony.Chain); ok {
+			q.chain, q.prover = chain, chains[id].ProverI
+			break
+		}
+	}
+	if q.chain == nil {
+		return nil, fmt.Errorf("path %s has no harmony chain", pathName)
+	}
+	if q.height, err = cmd.Flags().GetInt64(flagHeight); err != nil {
+		return nil, err
+	}
+	if q.height == 0 {
+		if q.height, err = q.chain.GetLatestHeight(); err != nil {
+			return nil, err
+		}
+	}
+	return &q, nil
+}
+
+// print prints the response in JSON. If any fields are given, the response is printed along with them.
+func (q *packetQuery) print(res proto.Message, fields map[string]interface{}) error {
+	bz, err := q.chain.Codec().MarshalJSON(res)
+	if err != nil {
+		return err
+	}
+	if len(fields) == 0 {
+		fmt.Println(string(bz))
+		return nil
+	}
+	fields["response"] = json.RawMessage(bz)
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+func parseSequences(args []string) ([]uint64, error) {
+	seqs := make([]uint64, len(args))
+	for i, arg := range args {
+		seq, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sequence %q: %w", arg, err)
+		}
+		seqs[i] = seq
+	}
+	return seqs, nil
+}
+
+func queryPacketCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "packet [path-name] [sequence]",
+		Short: "query a packet sent from Harmony",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			q, err := newPacketQuery(ctx, cmd, args[0])
+			if err != nil {
+				return err
+			}
+			seqs, err := parseSequences(args[1:])
+			if err != nil {
+				return err
+			}
+			packet, err := q.chain.QueryPacket(q.height, seqs[0])
+			if err != nil {
+				return err
+			}
+			return q.print(packet, nil)
+		},
+	}
+	return heightFlag(c)
+}
+
+func queryPacketCommitmentCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "packet-commitment [path-name] [sequence]",
+		Short: "query the commitment of a packet sent from Harmony",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			q, err := newPacketQuery(ctx, cmd, args[0])
+			if err != nil {
+				return err
+			}
+			seqs, err := parseSequences(args[1:])
+			if err != nil {
+				return err
+			}
+			prove, err := cmd.Flags().GetBool(flagProve)
+			if err != nil {
+				return err
+			}
+			if !prove {
+				res, err := q.chain.QueryPacketCommitment(q.height, seqs[0])
+				if err != nil {
+					return err
+				}
+				return q.print(res, nil)
+			}
+			res, err := q.prover.QueryPacketCommitmentWithProof(q.height, seqs[0])
+			if err != nil {
+				return err
+			}
+			path := q.chain.Path()
+			slot, err := hmylctypes.PacketCommitmentSlot(path.PortID, path.ChannelID, seqs[0])
+			if err != nil {
+				return err
+			}
+			return q.print(res, map[string]interface{}{"slot": hexutil.Bytes(slot)})
+		},
+	}
+	return proveFlag(heightFlag(c))
+}
+
+func queryPacketAckCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "packet-ack [path-name] [sequence]",
+		Short: "query the acknowledgement of a packet received on Harmony",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			q, err := newPacketQuery(ctx, cmd, args[0])
+			if err != nil {
+				return err
+			}
+			seqs, err := parseSequences(args[1:])
+			if err != nil {
+				return err
+			}
+			prove, err := cmd.Flags().GetBool(flagProve)
+			if err != nil {
+				return err
+			}
+			ack, err := q.chain.QueryPacketAcknowledgement(q.height, seqs[0])
+			if err != nil {
+				return err
+			}
+			fields := map[string]interface{}{"acknowledgement": hexutil.Bytes(ack)}
+			if !prove {
+				res, err := q.chain.QueryPacketAcknowledgementCommitment(q.height, seqs[0])
+				if err != nil {
+					return err
+				}
+				return q.print(res, fields)
+			}
+			res, err := q.prover.QueryPacketAcknowledgementCommitmentWithProof(q.height, seqs[0])
+			if err != nil {
+				return err
+			}
+			path := q.chain.Path()
+			slot, err := hmylctypes.PacketAcknowledgementCommitmentSlot(path.PortID, path.ChannelID, seqs[0])
+			if err != nil {
+				return err
+			}
+			fields["slot"] = hexutil.Bytes(slot)
+			return q.print(res, fields)
+		},
+	}
+	return proveFlag(heightFlag(c))
+}
+
+func queryUnreceivedPacketsCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "unreceived-packets [path-name] [sequence...]",
+		Short: "query the sequences of packets which haven't been received on Harmony",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			q, err := newPacketQuery(ctx, cmd, args[0])
+			if err != nil {
+				return err
+			}
+			seqs, err := parseSequences(args[1:])
+			if err != nil {
+				return err
+			}
+			unreceived, err := q.chain.QueryUnrecievedPackets(q.height, seqs)
+			if err != nil {
+				return err
+			}
+			return q.print(&chantypes.QueryUnreceivedPacketsResponse{Sequences: unreceived, Height: clienttypes.NewHeight(0, uint64(q.height))}, nil)
+		},
+	}
+	return heightFlag(c)
+}
+
+func queryUnreceivedAcksCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "unreceived-acks [path-name] [sequence...]",
+		Short: "query the sequences of packets sent from Harmony whose acknowledgements haven't been received",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			q, err := newPacketQuery(ctx, cmd, args[0])
+			if err != nil {
+				return err
+			}
+			seqs, err := parseSequences(args[1:])
+			if err != nil {
+				return err
+			}
+			unreceived, err := q.chain.QueryUnrecievedAcknowledgements(q.height, seqs)
+			if err != nil {
+				return err
+			}
+			return q.print(&chantypes.QueryUnreceivedAcksResponse{Sequences: unreceived, Height: clienttypes.NewHeight(0, uint64(q.height))}, nil)
+		},
+	}
+	return heightFlag(c)
+}
@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
+	hmylctypes "github.com/datachainlab/ibc-harmony-client/modules/light-clients/harmony/types"
 	"github.com/ethereum/go-ethereum/common"
+	proto "github.com/gogo/protobuf/proto"
 	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/harmony"
 	"github.com/mapdev33/yui-relayer/config"
 	"github.com/spf13/cobra"
@@ -20,15 +25,27 @@ func queryCmd(ctx *config.Context) *cobra.Command {
 
 	cmd.AddCommand(
 		queryBalanceCmd(ctx),
+		queryBankBalanceCmd(ctx),
+		queryTokenBalanceCmd(ctx),
+		queryClientsCmd(ctx),
+		queryConnectionsCmd(ctx),
+		queryChannelsCmd(ctx),
+		queryPacketCmd(ctx),
+		queryPacketCommitmentCmd(ctx),
+		queryPacketAckCmd(ctx),
+		queryUnreceivedPacketsCmd(ctx),
+		queryUnreceivedAcksCmd(ctx),
+		queryHeaderCmd(ctx),
 	)
 	return cmd
 }
 
 func queryBalanceCmd(ctx *config.Context) *cobra.Command {
 	c := &cobra.Command{
-		Use:   "balance [chain-id]",
-		Short: "query balance",
-		Args:  cobra.ExactArgs(1),
+		Use:        "balance [chain-id]",
+		Short:      "query balance",
+		Deprecated: "use bank-balance instead",
+		Args:       cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := ctx.Config.GetChain(args[0])
 			if err != nil {
@@ -50,7 +67,7 @@ func queryBalanceCmd(ctx *config.Context) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			fmt.Printf("%d %s\n", balance, bankId)
+			fmt.Printf("%s %s\n", harmony.FormatAmount(balance, chain.Config().Decimals(bankId)), bankId)
 			return nil
 		},
 	}
@@ -59,3 +76,207 @@ func queryBalanceCmd(ctx *config.Context) *cobra.Command {
 	c = bankIdFlags(c)
 	return c
 }
+
+// queryBankBalanceCmd prints a balance in ICS20Bank, which is of an ERC20 token or a token received over IBC
+func queryBankBalanceCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "bank-balance [chain-id]",
+		Short: "query a balance in ICS20Bank",
+		Long: "Query a balance in ICS20Bank. The balance is identified by --bank-id, such as a denom received over IBC," +
+			" or by the address of a deposited ERC20 token with --token. Without either, it is of token_address",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain, err := harmonyChain(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			owner, err := cmd.Flags().GetString(flagOwner)
+			if err != nil {
+				return err
+			}
+			bankId, err := cmd.Flags().GetString(flagBankId)
+			if err != nil {
+				return err
+			}
+			if bankId == "" {
+				token, err := tokenArg(cmd, chain)
+				if err != nil {
+					return err
+				}
+				bankId = harmony.BankDenom(token)
+			} else if cmd.Flags().Changed(flagToken) {
+				return fmt.Errorf("--%s and --%s can't be given at the same time", flagBankId, flagToken)
+			}
+			balance, err := chain.QueryBankBalance(common.HexToAddress(owner), bankId)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s %s\n", harmony.FormatAmount(balance, chain.Config().Decimals(bankId)), strings.ToLower(bankId))
+			return nil
+		},
+	}
+	c.Flags().String(flagBankId, "", "bank id")
+	return tokenFlag(ownerFlags(c))
+}
+
+// queryTokenBalanceCmd prints a balance of an ERC20 token outside ICS20Bank
+func queryTokenBalanceCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "token-balance [chain-id]",
+		Short: "query a balance of an ERC20 token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain, err := harmonyChain(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			owner, err := cmd.Flags().GetString(flagOwner)
+			if err != nil {
+				return err
+			}
+			token, err := tokenArg(cmd, chain)
+			if err != nil {
+				return err
+			}
+			balance, err := chain.QueryERC20Balance(token, common.HexToAddress(owner))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s %s\n", harmony.FormatAmount(balance, chain.Config().Decimals(harmony.BankDenom(token))), token.Hex())
+			return nil
+		},
+	}
+	return tokenFlag(ownerFlags(c))
+}
+
+func queryClientsCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "clients [chain-id]",
+		Short: "query all clients created on IBCHost",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryIdentified(ctx, cmd, args[0], func(chain *harmony.Chain, height int64) (proto.Message, error) {
+				return chain.QueryClients(height)
+			})
+		},
+	}
+	return heightFlag(c)
+}
+
+func queryConnectionsCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "connections [chain-id]",
+		Short: "query all connections created on IBCHost",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryIdentified(ctx, cmd, args[0], func(chain *harmony.Chain, height int64) (proto.Message, error) {
+				return chain.QueryConnections(height)
+			})
+		},
+	}
+	return heightFlag(c)
+}
+
+func queryChannelsCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "channels [chain-id]",
+		Short: "query all channels created on IBCHost",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return queryIdentified(ctx, cmd, args[0], func(chain *harmony.Chain, height int64) (proto.Message, error) {
+				return chain.QueryChannels(height)
+			})
+		},
+	}
+	return heightFlag(c)
+}
+
+// queryIdentified prints the result of the given query at the height of the flag in JSON
+func queryIdentified(ctx *config.Context, cmd *cobra.Command, chainID string, query func(*harmony.Chain, int64) (proto.Message, error)) error {
+	c, err := ctx.Config.GetChain(chainID)
+	if err != nil {
+		return err
+	}
+	chain, ok := c.ChainI.(*harmony.Chain)
+	if !ok {
+		return errors.New("invalid chain-id")
+	}
+	height, err := cmd.Flags().GetInt64(flagHeight)
+	if err != nil {
+		return err
+	}
+	res, err := query(chain, height)
+	if err != nil {
+		return err
+	}
+	bz, err := chain.Codec().MarshalJSON(res)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(bz))
+	return nil
+}
+
+func queryHeaderCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "header [chain-id] [height]",
+		Short: "query the header which the prover builds at a beacon height",
+		Long: "Print the decoded beacon and shard headers, the cross links, the beacon committee and the number of signers" +
+			" of the header which the prover builds at the given beacon height, along with the header submitted to the light client",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+			return inspectHeader(ctx, args[0], func(prover *harmony.Prover) (*hmylctypes.Header, error) {
+				return prover.QueryHeader(height)
+			})
+		},
+	}
+	c.AddCommand(queryEpochHeaderCmd(ctx))
+	return c
+}
+
+func queryEpochHeaderCmd(ctx *config.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "epoch [chain-id] [epoch]",
+		Short: "query the header of the last beacon block of an epoch",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			epoch, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+			return inspectHeader(ctx, args[0], func(prover *harmony.Prover) (*hmylctypes.Header, error) {
+				return prover.QueryEpochHeader(epoch)
+			})
+		},
+	}
+}
+
+// inspectHeader prints the decoded content of the header returned by the given query in JSON
+func inspectHeader(ctx *config.Context, chainID string, query func(*harmony.Prover) (*hmylctypes.Header, error)) error {
+	c, err := ctx.Config.GetChain(chainID)
+	if err != nil {
+		return err
+	}
+	prover, ok := c.ProverI.(*harmony.Prover)
+	if !ok {
+		return errors.New("invalid chain-id")
+	}
+	header, err := query(prover)
+	if err != nil {
+		return err
+	}
+	out, err := prover.InspectHeader(header)
+	if err != nil {
+		return err
+	}
+	bz, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(bz))
+	return nil
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
+	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/harmony"
+	"github.com/mapdev33/yui-relayer/core"
+)
+
+// nextSequenceRecvProver is a prover which can prove the next sequence to be received on an ordered channel
+type nextSequenceRecvProver interface {
+	QueryNextSequenceRecvWithProof(height int64) (*chantypes.QueryNextSequenceReceiveResponse, error)
+}
+
+// headerTimeProver is a prover which can tell the time of its headers
+type headerTimeProver interface {
+	HeaderTime(header core.HeaderI) (time.Time, error)
+}
+
+// headerTime returns the time of a header of the chain, which becomes the timestamp of the consensus state it creates
+func headerTime(chain *core.ProvableChain, header core.HeaderI) (time.Time, error) {
+	if h, ok := header.(interface{ GetTime() time.Time }); ok {
+		return h.GetTime(), nil
+	}
+	if prover, ok := chain.ProverI.(headerTimeProver); ok {
+		return prover.HeaderTime(header)
+	}
+	return time.Time{}, fmt.Errorf("the time of headers of %s can't be determined", chain.ChainID())
+}
+
+// timeoutPacket times out the packet of the given sequence sent from src, which has never been received on dst.
+// Since a timeout closes an ordered channel on src, the closing is confirmed on dst as well.
+func timeoutPacket(src, dst *core.ProvableChain, seq uint64) error {
+	if _, ok := src.ChainI.(*harmony.Chain); ok {
+		return fmt.Errorf("IBCHandler on %s doesn't process timeouts, so packets sent from it can't be timed out", src.ChainID())
+	}
+	srcChan, err := src.QueryChannel(0)
+	if err != nil {
+		return err
+	}
+	if srcChan.Channel.Ordering != chantypes.ORDERED {
+		return fmt.Errorf("timing out packets is only supported on ordered channels, but channel %s on %s is %s",
+			src.Path().ChannelID, src.ChainID(), srcChan.Channel.Ordering)
+	}
+	prover, ok := dst.ProverI.(nextSequenceRecvProver)
+	if !ok {
+		return fmt.Errorf("%s can't prove the next sequence to be received", dst.ChainID())
+	}
+
+	srcHeight, err := src.GetLatestHeight()
+	if err != nil {
+		return err
+	}
+	packet, err := src.QueryPacket(srcHeight, seq)
+	if err != nil {
+		return err
+	}
+
+	header, _, queryableHeight, err := dst.UpdateLightWithHeader()
+	if err != nil {
+		return err
+	}
+	// the proof height is in the revision of dst, which the timeout height of the packet may not be
+	proofHeight := clienttypes.NewHeight(header.GetHeight().GetRevisionNumber(), uint64(queryableHeight))
+	heightTimedOut := !packet.TimeoutHeight.IsZero() && proofHeight.GTE(packet.TimeoutHeight)
+	timestampTimedOut := false
+	if packet.TimeoutTimestamp != 0 {
+		t, err := headerTime(dst, header)
+		if err != nil {
+			return err
+		}
+		timestampTimedOut = uint64(t.UnixNano()) >= packet.TimeoutTimestamp
+	}
+	if !heightTimedOut && !timestampTimedOut {
+		return fmt.Errorf("packet %d hasn't timed out on %s at height %v yet: timeout height is %v and timeout timestamp is %d",
+			seq, dst.ChainID(), proofHeight, packet.TimeoutHeight, packet.TimeoutTimestamp)
+	}
+	res, err := prover.QueryNextSequenceRecvWithProof(queryableHeight)
+	if err != nil {
+		return err
+	}
+	if res.NextSequenceReceive > seq {
+		return fmt.Errorf("packet %d has already been received on %s", seq, dst.ChainID())
+	}
+
+	header, err = dst.SetupHeader(src, header)
+	if err != nil {
+		return err
+	}
+	signer, err := src.GetAddress()
+	if err != nil {
+		return err
+	}
+	tx := core.RelayMsgs{
+		Src: []sdk.Msg{
+			src.Path().UpdateClient(header, signer),
+			chantypes.NewMsgTimeout(*packet, res.NextSequenceReceive, res.Proof, res.ProofHeight, signer.String()),
+		},
+		Dst: []sdk.Msg{},
+	}
+	if tx.Send(src, dst); !tx.Succeeded {
+		return fmt.Errorf("failed to time out packet %d on %s", seq, src.ChainID())
+	}
+	log.Printf("★ Packet timed out: [%s]chan(%s) seq(%d)", src.ChainID(), src.Path().ChannelID, seq)
+
+	return closeChannel(src, dst)
+}
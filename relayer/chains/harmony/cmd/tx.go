@@ -3,6 +3,8 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"math/big"
+	"strconv"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	transfertypes "github.com/cosmos/ibc-go/modules/apps/transfer/types"
@@ -25,18 +27,148 @@ func txCmd(ctx *config.Context) *cobra.Command {
 
 	cmd.AddCommand(
 		depositCmd(ctx),
+		withdrawCmd(ctx),
 		xfersend(ctx),
+		recoverClientCmd(ctx),
+		channelCloseCmd(ctx),
+		timeoutCmd(ctx),
 	)
 	return cmd
 }
 
-// depositCmd represents the chain command
+// depositCmd moves an ERC20 token of the relayer into ICS20Bank, so that it can be transferred over IBC
 func depositCmd(ctx *config.Context) *cobra.Command {
 	c := &cobra.Command{
 		Use:   "deposit [chain-id]",
-		Short: "Depsit Commands",
-		Long:  "Commands to deposit token to ICS20TransferBank",
+		Short: "deposit an ERC20 token into ICS20Bank",
+		Long: "Approve ICS20Bank to spend an ERC20 token of the relayer, and deposit the amount into ICS20Bank for the receiver." +
+			" The balance in ICS20Bank is identified by the lower case address of the token",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain, err := harmonyChain(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			token, receiver, amount, err := bankTransferArgs(cmd, chain)
+			if err != nil {
+				return err
+			}
+			txHash, err := chain.TxDeposit(token, amount, receiver)
+			if err != nil {
+				return err
+			}
+			denom := harmony.BankDenom(token)
+			fmt.Printf("deposited %s %s for %s in %s\n", harmony.FormatAmount(amount, chain.Config().Decimals(denom)), denom, receiver.Hex(), txHash.Hex())
+			return nil
+		},
+	}
+	return bankTransferFlags(c)
+}
+
+// withdrawCmd moves an ERC20 token of the relayer out of ICS20Bank
+func withdrawCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "withdraw [chain-id]",
+		Short: "withdraw an ERC20 token from ICS20Bank",
+		Long:  "Withdraw the amount of an ERC20 token of the relayer from ICS20Bank to the receiver",
 		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain, err := harmonyChain(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			token, receiver, amount, err := bankTransferArgs(cmd, chain)
+			if err != nil {
+				return err
+			}
+			txHash, err := chain.TxWithdraw(token, amount, receiver)
+			if err != nil {
+				return err
+			}
+			denom := harmony.BankDenom(token)
+			fmt.Printf("withdrew %s %s to %s in %s\n", harmony.FormatAmount(amount, chain.Config().Decimals(denom)), denom, receiver.Hex(), txHash.Hex())
+			return nil
+		},
+	}
+	return bankTransferFlags(c)
+}
+
+// harmonyChain returns the Harmony chain of the given chain ID
+func harmonyChain(ctx *config.Context, chainID string) (*harmony.Chain, error) {
+	c, err := ctx.Config.GetChain(chainID)
+	if err != nil {
+		return nil, err
+	}
+	chain, ok := c.ChainI.(*harmony.Chain)
+	if !ok {
+		return nil, errors.New("invalid chain-id")
+	}
+	return chain, nil
+}
+
+// tokenArg returns the ERC20 token address of the flag, or token_address of the chain
+func tokenArg(cmd *cobra.Command, chain *harmony.Chain) (common.Address, error) {
+	token, err := cmd.Flags().GetString(flagToken)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if token == "" {
+		return chain.Config().SimpleTokenAddress(), nil
+	}
+	if !common.IsHexAddress(token) {
+		return common.Address{}, fmt.Errorf("invalid token address %q", token)
+	}
+	return common.HexToAddress(token), nil
+}
+
+// bankTransferArgs returns the token, the receiver and the amount of bankTransferFlags
+func bankTransferArgs(cmd *cobra.Command, chain *harmony.Chain) (token, receiver common.Address, amount *big.Int, err error) {
+	if token, err = tokenArg(cmd, chain); err != nil {
+		return
+	}
+	r, err := cmd.Flags().GetString(flagReceiver)
+	if err != nil {
+		return
+	}
+	switch {
+	case r == "":
+		if receiver, err = chain.RelayerAddress(); err != nil {
+			return
+		}
+	case common.IsHexAddress(r):
+		receiver = common.HexToAddress(r)
+	default:
+		err = fmt.Errorf("invalid receiver address %q", r)
+		return
+	}
+	amount, err = amountArg(cmd, chain, harmony.BankDenom(token))
+	return
+}
+
+// amountArg parses the amount flag with the decimals of the denom
+func amountArg(cmd *cobra.Command, chain *harmony.Chain, denom string) (*big.Int, error) {
+	a, err := cmd.Flags().GetString(flagAmount)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := harmony.ParseAmount(a, chain.Config().Decimals(denom))
+	if err != nil {
+		return nil, err
+	}
+	if amount.Sign() == 0 {
+		return nil, errors.New("amount must be positive")
+	}
+	return amount, nil
+}
+
+// recoverClientCmd replaces the state of an expired or frozen client with the state of a substitute client
+func recoverClientCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "recover-client [chain-id] [subject-client-id] [substitute-client-id]",
+		Short: "recover an expired or frozen client with a substitute client",
+		Long: "Overwrite the state of an expired or frozen client on IBCHost with the latest state of a substitute client." +
+			" The relayer key must be the owner of IBCHost",
+		Args: cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := ctx.Config.GetChain(args[0])
 			if err != nil {
@@ -46,21 +178,67 @@ func depositCmd(ctx *config.Context) *cobra.Command {
 			if !ok {
 				return errors.New("invalid chain-id")
 			}
-			owner, err := cmd.Flags().GetString(flagOwner)
+			if err := chain.TxRecoverClient(args[1], args[2]); err != nil {
+				return err
+			}
+			fmt.Printf("recovered client %s with %s\n", args[1], args[2])
+			return nil
+		},
+	}
+	return c
+}
+
+// channelCloseCmd closes the channel of a path on a given chain and confirms it on the counterparty
+func channelCloseCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "channel-close [path-name] [chain-id]",
+		Short: "close the channel of a path",
+		Long: "Close the channel of a path on the given chain, and then confirm the closing on the counterparty." +
+			" Since the transfer module of ibc-go rejects closing its channels, channels of the transfer port can only be closed from Harmony",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, src, dst, err := ctx.Config.ChainsFromPath(args[0])
 			if err != nil {
 				return err
 			}
-			bankId, err := cmd.Flags().GetString(flagBankId)
+			switch args[1] {
+			case src:
+			case dst:
+				src, dst = dst, src
+			default:
+				return fmt.Errorf("not found chain '%v' in the path", args[1])
+			}
+			return closeChannel(c[src], c[dst])
+		},
+	}
+	return c
+}
+
+// timeoutCmd times out a packet on an ordered channel, and closes the channel on both chains
+func timeoutCmd(ctx *config.Context) *cobra.Command {
+	c := &cobra.Command{
+		Use:   "timeout [path-name] [chain-id] [sequence]",
+		Short: "time out a packet on an ordered channel",
+		Long: "Time out a packet sent from the given chain, which has never been received on the counterparty." +
+			" Since the timeout closes the ordered channel, the closing is confirmed on the counterparty as well",
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, src, dst, err := ctx.Config.ChainsFromPath(args[0])
 			if err != nil {
 				return err
 			}
-			balance, err := chain.QueryBankBalance(common.HexToAddress(owner), bankId)
+			switch args[1] {
+			case src:
+			case dst:
+				src, dst = dst, src
+			default:
+				return fmt.Errorf("not found chain '%v' in the path", args[1])
+			}
+			seq, err := strconv.ParseUint(args[2], 10, 64)
 			if err != nil {
 				return err
 			}
-			fmt.Printf("%d\n", balance)
-			return nil
-
+			return timeoutPacket(c[src], c[dst], seq)
 		},
 	}
 	return c
@@ -83,17 +261,17 @@ func xfersend(ctx *config.Context) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			_, ok := c.ChainI.(*harmony.Chain)
+			chain, ok := c.ChainI.(*harmony.Chain)
 			if !ok {
 				return errors.New("invalid chain-id")
 			}
 
-			amount, err := cmd.Flags().GetUint64(flagAmount)
+			// XXX want to support all denom format
+			d, err := cmd.Flags().GetString(flagDenom)
 			if err != nil {
 				return err
 			}
-			// XXX want to support all denom format
-			d, err := cmd.Flags().GetString(flagDenom)
+			amount, err := amountArg(cmd, chain, d)
 			if err != nil {
 				return err
 			}
@@ -101,7 +279,7 @@ func xfersend(ctx *config.Context) *cobra.Command {
 			fmt.Println("============================== denom: ", denom)
 			token := sdk.Coin{
 				Denom:  denom.GetFullDenomPath(),
-				Amount: sdk.Int(sdk.NewUint(amount)),
+				Amount: sdk.NewIntFromBigInt(amount),
 			}
 			fmt.Println("============================== token: ", token)
 			if denom.Path != "" {
@@ -120,6 +298,15 @@ func xfersend(ctx *config.Context) *cobra.Command {
 			}
 			fmt.Printf("receiver: %s", hexutil.Encode(receiverAcc.Bytes()))
 
+			counterparty, err := ctx.Config.GetChain(path.Src.ChainID)
+			if err != nil {
+				return err
+			}
+			timeoutHeight, err := transferTimeout(cmd, counterparty.ChainID(), counterparty.GetLatestHeight)
+			if err != nil {
+				return err
+			}
+
 			tx := core.RelayMsgs{
 				Src: []sdk.Msg{},
 				Dst: []sdk.Msg{
@@ -129,10 +316,8 @@ func xfersend(ctx *config.Context) *cobra.Command {
 						token,
 						"", // not used
 						hexutil.Encode(receiverAcc.Bytes()),
-						// TODO timeout height
-						types.NewHeight(0, 10000),
-						// TODO timeout timestamp
-						uint64(0),
+						timeoutHeight,
+						0,
 					),
 				},
 			}
@@ -145,5 +330,32 @@ func xfersend(ctx *config.Context) *cobra.Command {
 			return nil
 		},
 	}
-	return sendTransferFlags(cmd)
+	return transferTimeoutFlags(sendTransferFlags(cmd))
+}
+
+// transferTimeout returns the timeout height of a transfer, which is the offset from the latest height of the counterparty.
+// A timeout timestamp is rejected up front, because the deployed ICS20TransferBank only takes a timeout height.
+func transferTimeout(cmd *cobra.Command, counterpartyChainID string, latestHeight func() (int64, error)) (types.Height, error) {
+	heightOffset, err := cmd.Flags().GetUint64(flagTimeoutHeightOffset)
+	if err != nil {
+		return types.Height{}, err
+	}
+	timeOffset, err := cmd.Flags().GetDuration(flagTimeoutTimeOffset)
+	if err != nil {
+		return types.Height{}, err
+	}
+	switch {
+	case cmd.Flags().Changed(flagTimeoutTimeOffset) && cmd.Flags().Changed(flagTimeoutHeightOffset):
+		return types.Height{}, fmt.Errorf("cannot set both --%s and --%s, choose one", flagTimeoutHeightOffset, flagTimeoutTimeOffset)
+	case cmd.Flags().Changed(flagTimeoutTimeOffset) || timeOffset != 0:
+		return types.Height{}, fmt.Errorf("--%s is not supported, because sendTransfer of ICS20TransferBank doesn't take a timeout timestamp", flagTimeoutTimeOffset)
+	case heightOffset == 0:
+		return types.Height{}, fmt.Errorf("--%s must be positive", flagTimeoutHeightOffset)
+	}
+	height, err := latestHeight()
+	if err != nil {
+		return types.Height{}, err
+	}
+	revision := types.ParseChainID(counterpartyChainID)
+	return types.NewHeight(revision, uint64(height)+heightOffset), nil
 }
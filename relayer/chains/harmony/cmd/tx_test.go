@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/ibc-go/modules/core/02-client/types"
+	"github.com/spf13/cobra"
+)
+
+func TestTransferTimeout(t *testing.T) {
+	latestHeight := func() (int64, error) { return 100, nil }
+	cases := []struct {
+		name     string
+		flags    map[string]string
+		expected types.Height
+		err      string
+	}{
+		{"default", nil, types.NewHeight(1, 1100), ""},
+		{"height offset", map[string]string{flagTimeoutHeightOffset: "10"}, types.NewHeight(1, 110), ""},
+		{"zero height offset", map[string]string{flagTimeoutHeightOffset: "0"}, types.Height{}, "must be positive"},
+		{"time offset", map[string]string{flagTimeoutTimeOffset: "1h"}, types.Height{}, "not supported"},
+		{"zero time offset", map[string]string{flagTimeoutTimeOffset: "0s"}, types.Height{}, "not supported"},
+		{"both offsets", map[string]string{flagTimeoutHeightOffset: "10", flagTimeoutTimeOffset: "1h"}, types.Height{}, "cannot set both"},
+	}
+	for _, c := range cases {
+		cmd := transferTimeoutFlags(&cobra.Command{})
+		for name, value := range c.flags {
+			if err := cmd.Flags().Set(name, value); err != nil {
+				t.Fatal(err)
+			}
+		}
+		height, err := transferTimeout(cmd, "ibc-1", latestHeight)
+		if c.err != "" {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("%s: expected an error containing %q, but got %v", c.name, c.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if !height.EQ(c.expected) {
+			t.Errorf("%s: expected %v, but got %v", c.name, c.expected, height)
+		}
+	}
+
+	// an error on querying the latest height is returned
+	cmd := transferTimeoutFlags(&cobra.Command{})
+	if _, err := transferTimeout(cmd, "ibc-1", func() (int64, error) { return 0, errors.New("unreachable") }); err == nil {
+		t.Error("an error on querying the latest height must be returned")
+	}
+}
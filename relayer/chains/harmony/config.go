@@ -2,11 +2,13 @@ package harmony
 
 import (
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	sdkcommon "github.com/harmony-one/go-sdk/pkg/common"
 	"github.com/harmony-one/harmony/numeric"
+	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/configref"
 	"github.com/mapdev33/yui-relayer/core"
 )
 
@@ -17,6 +19,43 @@ func (c ChainConfig) Build() (core.ChainI, error) {
 	return NewChain(c)
 }
 
+// Resolve returns a copy of the config whose "${ENV}" and "file://" references are resolved.
+// The references are allowed in the private keys, the RPC endpoints and the gas settings.
+func (c ChainConfig) Resolve() (ChainConfig, error) {
+	c.ShardRpcFallbackAddrs = append([]string(nil), c.ShardRpcFallbackAddrs...)
+	c.BeaconRpcFallbackAddrs = append([]string(nil), c.BeaconRpcFallbackAddrs...)
+	fields := []configref.Field{
+		{Name: "shard_rpc_addr", Value: &c.ShardRpcAddr},
+		{Name: "beacon_rpc_addr", Value: &c.BeaconRpcAddr},
+		{Name: "shard_private_key", Value: &c.ShardPrivateKey},
+		{Name: "beacon_private_key", Value: &c.BeaconPrivateKey},
+	}
+	for i := range c.ShardRpcFallbackAddrs {
+		fields = append(fields, configref.Field{Name: fmt.Sprintf("shard_rpc_fallback_addrs[%d]", i), Value: &c.ShardRpcFallbackAddrs[i]})
+	}
+	for i := range c.BeaconRpcFallbackAddrs {
+		fields = append(fields, configref.Field{Name: fmt.Sprintf("beacon_rpc_fallback_addrs[%d]", i), Value: &c.BeaconRpcFallbackAddrs[i]})
+	}
+	if err := configref.ResolveFields(fields...); err != nil {
+		return ChainConfig{}, err
+	}
+
+	var err error
+	if c.GasLimitRef != "" {
+		if c.GasLimit, err = configref.ResolveUint64("gas_limit_ref", c.GasLimitRef); err != nil {
+			return ChainConfig{}, err
+		}
+		c.GasLimitRef = ""
+	}
+	if c.GasPriceRef != "" {
+		if c.GasPrice, err = configref.ResolveInt64("gas_price_ref", c.GasPriceRef); err != nil {
+			return ChainConfig{}, err
+		}
+		c.GasPriceRef = ""
+	}
+	return c, nil
+}
+
 func (c ChainConfig) IBCHostAddress() common.Address {
 	return common.HexToAddress(c.IbcHostAddress)
 }
@@ -33,22 +72,159 @@ func (c ChainConfig) ICS20BankAddress() common.Address {
 	return common.HexToAddress(c.Ics20BankAddress)
 }
 
+func (c ChainConfig) ICS20TransferBankAddress() common.Address {
+	return common.HexToAddress(c.Ics20TransferBankAddress)
+}
+
 func (c ChainConfig) ChainID() (*sdkcommon.ChainID, error) {
 	return sdkcommon.StringToChainID(c.HarmonyChainId)
 }
 
+// LogsStartHeight returns the block from which the events of IBCHost and IBCHandler are searched.
+// ibc_host_deployment_height is required except on localnet, since searching a long chain from the genesis takes
+// a huge number of getLogs requests.
+func (c ChainConfig) LogsStartHeight() (uint64, error) {
+	if c.IbcHostDeploymentHeight == 0 && c.HarmonyChainId != "localnet" {
+		return 0, fmt.Errorf("ibc_host_deployment_height is required on %s to search the events of IBCHost and IBCHandler", c.HarmonyChainId)
+	}
+	return c.IbcHostDeploymentHeight, nil
+}
+
+// GasPriceDec returns the gas price for the Harmony SDK, which takes it in gwei as gas_price
 func (c ChainConfig) GasPriceDec() numeric.Dec {
 	return numeric.NewDec(c.GasPrice)
 }
 
+// GasPriceWei returns the gas price in wei for Ethereum compatible transactions
+func (c ChainConfig) GasPriceWei() *big.Int {
+	return new(big.Int).Mul(big.NewInt(c.GasPrice), big.NewInt(1e9))
+}
+
+const (
+	defaultMaxBlockLag   = 10
+	defaultRPCMaxRetries = 3
+	defaultPruningWindow = 128
+)
+
+// ShardRPCAddrs returns the shard endpoints in order of preference
+func (c ChainConfig) ShardRPCAddrs() []string {
+	return append([]string{c.ShardRpcAddr}, c.ShardRpcFallbackAddrs...)
+}
+
+// BeaconRPCAddrs returns the beacon endpoints in order of preference
+func (c ChainConfig) BeaconRPCAddrs() []string {
+	return append([]string{c.BeaconRpcAddr}, c.BeaconRpcFallbackAddrs...)
+}
+
+func (c ChainConfig) MaxBlockLagOrDefault() uint64 {
+	if c.MaxBlockLag == 0 {
+		return defaultMaxBlockLag
+	}
+	return c.MaxBlockLag
+}
+
+func (c ChainConfig) RPCMaxRetriesOrDefault() uint {
+	if c.RpcMaxRetries == 0 {
+		return defaultRPCMaxRetries
+	}
+	return uint(c.RpcMaxRetries)
+}
+
+func (c ChainConfig) PruningWindowOrDefault() uint64 {
+	if c.PruningWindow == 0 {
+		return defaultPruningWindow
+	}
+	return c.PruningWindow
+}
+
+// RPCTimeoutDuration returns the timeout of each RPC request, or 0 if it isn't set
+func (c ChainConfig) RPCTimeoutDuration() (time.Duration, error) {
+	if c.RpcTimeout == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(c.RpcTimeout)
+}
+
+func (c ChainConfig) ClientOptions() ClientOptions {
+	timeout, _ := c.RPCTimeoutDuration()
+	return ClientOptions{
+		MaxBlockLag:   c.MaxBlockLagOrDefault(),
+		MaxRetries:    c.RPCMaxRetriesOrDefault(),
+		PruningWindow: c.PruningWindowOrDefault(),
+		Timeout:       timeout,
+	}
+}
+
+// NewShardClient returns a client of the shard endpoints
+func (c ChainConfig) NewShardClient() *Client {
+	return NewHarmonyClientWithFailover(c.ShardRPCAddrs(), c.ClientOptions())
+}
+
+// NewBeaconClient returns a client of the beacon endpoints
+func (c ChainConfig) NewBeaconClient() *Client {
+	return NewHarmonyClientWithFailover(c.BeaconRPCAddrs(), c.ClientOptions())
+}
+
+const (
+	TrackBeacon = "beacon"
+	TrackShard  = "shard"
+
+	defaultMaxClockDrift = 10 * time.Minute
+)
+
 func (c ProverConfig) Build(chain core.ChainI) (core.ProverI, error) {
 	hmyChain, ok := chain.(*Chain)
 	if !ok {
 		return nil, fmt.Errorf("invalid chain type")
 	}
+	if err := c.Validate(hmyChain.config.ShardId); err != nil {
+		return nil, err
+	}
 	return NewProver(hmyChain, c)
 }
 
+// Validate returns an error if the config is invalid for a chain on the given shard
+func (c ProverConfig) Validate(shardId uint32) error {
+	if _, err := c.TrustingPeriodDuration(); err != nil {
+		return fmt.Errorf("invalid trusting_period: %w", err)
+	}
+	if _, err := c.MaxClockDriftDuration(); err != nil {
+		return fmt.Errorf("invalid max_clock_drift: %w", err)
+	}
+	switch c.Track {
+	case "":
+	case TrackBeacon:
+		if shardId != 0 {
+			return fmt.Errorf("track %q requires shard_id 0, but got %d", c.Track, shardId)
+		}
+	case TrackShard:
+		if shardId == 0 {
+			return fmt.Errorf("track %q requires non-zero shard_id", c.Track)
+		}
+	default:
+		return fmt.Errorf("invalid track: %q", c.Track)
+	}
+	if c.InitialHeight > 0 && c.InitialEpoch > 0 {
+		return fmt.Errorf("initial_height and initial_epoch can't be set at the same time")
+	}
+	return nil
+}
+
 func (c ProverConfig) TrustingPeriodDuration() (time.Duration, error) {
 	return time.ParseDuration(c.TrustingPeriod)
 }
+
+func (c ProverConfig) MaxClockDriftDuration() (time.Duration, error) {
+	if c.MaxClockDrift == "" {
+		return defaultMaxClockDrift, nil
+	}
+	return time.ParseDuration(c.MaxClockDrift)
+}
+
+// TracksBeacon returns true if the client follows beacon headers only
+func (c ProverConfig) TracksBeacon(shardId uint32) bool {
+	if c.Track == "" {
+		return shardId == 0
+	}
+	return c.Track == TrackBeacon
+}
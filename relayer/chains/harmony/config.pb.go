@@ -45,7 +45,31 @@ type ChainConfig struct {
 	// for convenience of demonstration
 	TokenAddress string `protobuf:"bytes,12,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
 	GasLimit     uint64 `protobuf:"varint,13,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
-	GasPrice     int64  `protobuf:"varint,14,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	// gas price in gwei (1e-9 ONE), which is multiplied by 1e9 for Ethereum compatible transactions in wei
+	GasPrice int64 `protobuf:"varint,14,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	// endpoints used when shard_rpc_addr is unhealthy
+	ShardRpcFallbackAddrs []string `protobuf:"bytes,15,rep,name=shard_rpc_fallback_addrs,json=shardRpcFallbackAddrs,proto3" json:"shard_rpc_fallback_addrs,omitempty"`
+	// endpoints used when beacon_rpc_addr is unhealthy
+	BeaconRpcFallbackAddrs []string `protobuf:"bytes,16,rep,name=beacon_rpc_fallback_addrs,json=beaconRpcFallbackAddrs,proto3" json:"beacon_rpc_fallback_addrs,omitempty"`
+	// an endpoint is unhealthy if its latest block lags behind the others by more than this. defaults to 10
+	MaxBlockLag uint64 `protobuf:"varint,17,opt,name=max_block_lag,json=maxBlockLag,proto3" json:"max_block_lag,omitempty"`
+	// the number of retries of a failed RPC request. defaults to 3
+	RpcMaxRetries uint32 `protobuf:"varint,18,opt,name=rpc_max_retries,json=rpcMaxRetries,proto3" json:"rpc_max_retries,omitempty"`
+	// the number of recent blocks whose state non-archive nodes keep. defaults to 128
+	PruningWindow uint64 `protobuf:"varint,19,opt,name=pruning_window,json=pruningWindow,proto3" json:"pruning_window,omitempty"`
+	// the timeout of each RPC request such as "30s". defaults to the timeout of the global config
+	RpcTimeout string `protobuf:"bytes,20,opt,name=rpc_timeout,json=rpcTimeout,proto3" json:"rpc_timeout,omitempty"`
+	// the header versions and the epochs from which they are used. defaults to v3 from epoch 0
+	HeaderVersions []*HeaderVersion `protobuf:"bytes,21,rep,name=header_versions,json=headerVersions,proto3" json:"header_versions,omitempty"`
+	// a "${ENV}" or "file://" reference to gas_limit, which overrides gas_limit if set
+	GasLimitRef string `protobuf:"bytes,22,opt,name=gas_limit_ref,json=gasLimitRef,proto3" json:"gas_limit_ref,omitempty"`
+	// a "${ENV}" or "file://" reference to gas_price, which overrides gas_price if set
+	GasPriceRef string `protobuf:"bytes,23,opt,name=gas_price_ref,json=gasPriceRef,proto3" json:"gas_price_ref,omitempty"`
+	// the decimals of denoms used to parse and display amounts. amounts of other denoms are in the smallest unit
+	DenomUnits []*DenomUnit `protobuf:"bytes,24,rep,name=denom_units,json=denomUnits,proto3" json:"denom_units,omitempty"`
+	// the block at which IBCHost was deployed, from which the events of IBCHost and IBCHandler are searched.
+	// It is required unless harmony_chain_id is localnet, and is set by the deploy and config generate commands
+	IbcHostDeploymentHeight uint64 `protobuf:"varint,25,opt,name=ibc_host_deployment_height,json=ibcHostDeploymentHeight,proto3" json:"ibc_host_deployment_height,omitempty"`
 }
 
 func (m *ChainConfig) Reset()         { *m = ChainConfig{} }
@@ -81,15 +105,106 @@ func (m *ChainConfig) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ChainConfig proto.InternalMessageInfo
 
+type DenomUnit struct {
+	// an ICS20Bank ID such as the lower case address of an ERC20 token, or the base denom of tokens received over IBC
+	Denom string `protobuf:"bytes,1,opt,name=denom,proto3" json:"denom,omitempty"`
+	// e.g. 18 for most ERC20 tokens, and 6 for the exponent of a Cosmos coin
+	Decimals uint32 `protobuf:"varint,2,opt,name=decimals,proto3" json:"decimals,omitempty"`
+}
+
+func (m *DenomUnit) Reset()         { *m = DenomUnit{} }
+func (m *DenomUnit) String() string { return proto.CompactTextString(m) }
+func (*DenomUnit) ProtoMessage()    {}
+func (*DenomUnit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3d1a31f40ed93c46, []int{1}
+}
+func (m *DenomUnit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DenomUnit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DenomUnit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DenomUnit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DenomUnit.Merge(m, src)
+}
+func (m *DenomUnit) XXX_Size() int {
+	return m.Size()
+}
+func (m *DenomUnit) XXX_DiscardUnknown() {
+	xxx_messageInfo_DenomUnit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DenomUnit proto.InternalMessageInfo
+
+type HeaderVersion struct {
+	// the first epoch whose headers are of the version
+	Epoch uint64 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// "v0", "v1", "v2" or "v3"
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (m *HeaderVersion) Reset()         { *m = HeaderVersion{} }
+func (m *HeaderVersion) String() string { return proto.CompactTextString(m) }
+func (*HeaderVersion) ProtoMessage()    {}
+func (*HeaderVersion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3d1a31f40ed93c46, []int{2}
+}
+func (m *HeaderVersion) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HeaderVersion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HeaderVersion.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *HeaderVersion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeaderVersion.Merge(m, src)
+}
+func (m *HeaderVersion) XXX_Size() int {
+	return m.Size()
+}
+func (m *HeaderVersion) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeaderVersion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeaderVersion proto.InternalMessageInfo
+
 type ProverConfig struct {
 	TrustingPeriod string `protobuf:"bytes,1,opt,name=trusting_period,json=trustingPeriod,proto3" json:"trusting_period,omitempty"`
+	// headers whose timestamp is ahead of the local clock by more than this are rejected. defaults to "10m".
+	// It's only checked by the relayer before submitting headers, and is not enforced by the client on the counterparty
+	MaxClockDrift string `protobuf:"bytes,2,opt,name=max_clock_drift,json=maxClockDrift,proto3" json:"max_clock_drift,omitempty"`
+	// the headers with which the client is created and updated: "beacon" for beacon headers, which requires shard_id 0,
+	// or "shard" for crosslinked pairs of beacon and shard headers, which requires a non-zero shard_id.
+	// defaults to "beacon" if shard_id is 0, otherwise "shard"
+	Track string `protobuf:"bytes,3,opt,name=track,proto3" json:"track,omitempty"`
+	// create a client from the header at the given beacon height instead of the latest one
+	InitialHeight uint64 `protobuf:"varint,4,opt,name=initial_height,json=initialHeight,proto3" json:"initial_height,omitempty"`
+	// create a client from the last beacon header of the given epoch instead of the latest one
+	InitialEpoch uint64 `protobuf:"varint,5,opt,name=initial_epoch,json=initialEpoch,proto3" json:"initial_epoch,omitempty"`
 }
 
 func (m *ProverConfig) Reset()         { *m = ProverConfig{} }
 func (m *ProverConfig) String() string { return proto.CompactTextString(m) }
 func (*ProverConfig) ProtoMessage()    {}
 func (*ProverConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3d1a31f40ed93c46, []int{1}
+	return fileDescriptor_3d1a31f40ed93c46, []int{3}
 }
 func (m *ProverConfig) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -120,6 +235,8 @@ var xxx_messageInfo_ProverConfig proto.InternalMessageInfo
 
 func init() {
 	proto.RegisterType((*ChainConfig)(nil), "relayer.chains.harmony.config.ChainConfig")
+	proto.RegisterType((*DenomUnit)(nil), "relayer.chains.harmony.config.DenomUnit")
+	proto.RegisterType((*HeaderVersion)(nil), "relayer.chains.harmony.config.HeaderVersion")
 	proto.RegisterType((*ProverConfig)(nil), "relayer.chains.harmony.config.ProverConfig")
 }
 
@@ -128,38 +245,62 @@ func init() {
 }
 
 var fileDescriptor_3d1a31f40ed93c46 = []byte{
-	// 493 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0xd3, 0xcf, 0x6e, 0xd3, 0x4e,
-	0x10, 0x07, 0xf0, 0xf8, 0xd7, 0xfc, 0x9a, 0x64, 0x9b, 0x7f, 0x35, 0x1c, 0x5c, 0x2a, 0xac, 0xa8,
-	0x45, 0x60, 0xa1, 0xc6, 0x46, 0x70, 0xe0, 0xc4, 0xa1, 0xcd, 0x85, 0x08, 0x0e, 0x51, 0xc4, 0x89,
-	0x8b, 0xb5, 0xde, 0xdd, 0xae, 0x57, 0x89, 0x77, 0xad, 0xdd, 0x6d, 0xa5, 0xbc, 0x05, 0xef, 0xc3,
-	0x0b, 0xf4, 0xd8, 0x23, 0x47, 0x48, 0x5e, 0x04, 0x79, 0xd6, 0x0e, 0x41, 0xe2, 0x96, 0xf9, 0xce,
-	0x67, 0x26, 0x89, 0x35, 0x46, 0x97, 0x9a, 0xad, 0xf1, 0x86, 0xe9, 0x84, 0xe4, 0x58, 0x48, 0x93,
-	0xe4, 0x58, 0x17, 0x4a, 0x6e, 0x12, 0xa2, 0xe4, 0xad, 0xe0, 0x71, 0xa9, 0x95, 0x55, 0xfe, 0xf3,
-	0x1a, 0xc5, 0x0e, 0xc5, 0x35, 0x8a, 0x1d, 0x7a, 0xf6, 0x94, 0x2b, 0xae, 0x40, 0x26, 0xd5, 0x27,
-	0x37, 0x74, 0xf1, 0xbd, 0x8d, 0x4e, 0x66, 0x95, 0x9f, 0x81, 0xf2, 0xcf, 0x50, 0x17, 0xc6, 0x53,
-	0x41, 0x03, 0x6f, 0xe2, 0x45, 0xbd, 0x65, 0x07, 0xea, 0x39, 0xf5, 0x23, 0x34, 0xae, 0x57, 0xa6,
-	0x7b, 0xf2, 0x1f, 0x90, 0x61, 0x9d, 0xcf, 0x6a, 0x79, 0x86, 0xba, 0x26, 0xc7, 0x9a, 0x56, 0xe2,
-	0x68, 0xe2, 0x45, 0x83, 0x65, 0x07, 0xea, 0x39, 0xf5, 0x5f, 0xa0, 0xa1, 0x6b, 0xe9, 0x92, 0xa4,
-	0x98, 0x52, 0x1d, 0xb4, 0x61, 0x45, 0x1f, 0xd2, 0x65, 0x49, 0xae, 0x29, 0xd5, 0xfe, 0x4b, 0x34,
-	0xca, 0x18, 0x26, 0x4a, 0xfe, 0x61, 0xff, 0x03, 0x1b, 0xb8, 0xb8, 0x71, 0xaf, 0xd1, 0xa9, 0xdb,
-	0x56, 0x6a, 0x71, 0x8f, 0x2d, 0x4b, 0x57, 0x6c, 0x13, 0x1c, 0x83, 0x1c, 0x41, 0x63, 0xe1, 0xf2,
-	0x4f, 0x6c, 0xe3, 0x5f, 0x21, 0xbf, 0xde, 0x79, 0x88, 0x3b, 0x80, 0xc7, 0xae, 0x73, 0xa0, 0x23,
-	0x34, 0x16, 0x19, 0x49, 0x73, 0x65, 0x2c, 0x7c, 0x3f, 0x33, 0x26, 0xe8, 0xba, 0x3f, 0x2b, 0x32,
-	0xf2, 0x51, 0x19, 0x7b, 0xed, 0x52, 0x3f, 0x46, 0x4f, 0x40, 0x62, 0x49, 0xd7, 0x4c, 0xef, 0x71,
-	0x0f, 0xf0, 0x69, 0x85, 0x5d, 0xa7, 0xf1, 0x57, 0xc8, 0x17, 0xc4, 0xbc, 0x7d, 0x93, 0x66, 0x58,
-	0xae, 0xf6, 0x1c, 0xb9, 0xdf, 0x01, 0x9d, 0x1b, 0x2c, 0x57, 0x8d, 0xfe, 0x80, 0xce, 0x9d, 0xb6,
-	0x1a, 0x4b, 0x73, 0xcb, 0xf4, 0xdf, 0x63, 0x27, 0x30, 0x16, 0x00, 0xf9, 0x52, 0x8b, 0xc3, 0xf1,
-	0x4b, 0x34, 0xb0, 0x6a, 0xc5, 0xe4, 0x7e, 0xa0, 0xef, 0x9e, 0x36, 0x84, 0x0d, 0x3a, 0x47, 0x3d,
-	0x8e, 0x4d, 0xba, 0x16, 0x85, 0xb0, 0xc1, 0x60, 0xe2, 0x45, 0xed, 0x65, 0x97, 0x63, 0xf3, 0xb9,
-	0xaa, 0x9b, 0x66, 0xa9, 0x05, 0x61, 0xc1, 0x70, 0xe2, 0x45, 0x47, 0xd0, 0x5c, 0x54, 0xf5, 0xc5,
-	0x7b, 0xd4, 0x5f, 0x68, 0x75, 0xcf, 0x74, 0x7d, 0x3d, 0xaf, 0xd0, 0xc8, 0xea, 0x3b, 0x63, 0x85,
-	0xe4, 0x69, 0xc9, 0xb4, 0x50, 0xcd, 0x11, 0x0d, 0x9b, 0x78, 0x01, 0xe9, 0x0d, 0x7f, 0xf8, 0x15,
-	0xb6, 0x1e, 0xb6, 0xa1, 0xf7, 0xb8, 0x0d, 0xbd, 0x9f, 0xdb, 0xd0, 0xfb, 0xb6, 0x0b, 0x5b, 0x8f,
-	0xbb, 0xb0, 0xf5, 0x63, 0x17, 0xb6, 0xbe, 0xce, 0xb9, 0xb0, 0xf9, 0x5d, 0x16, 0x13, 0x55, 0x24,
-	0x14, 0x5b, 0x0c, 0xe7, 0xb6, 0xc6, 0x59, 0x73, 0xf7, 0x53, 0xa2, 0x4c, 0xa1, 0xcc, 0x34, 0xd3,
-	0x82, 0x72, 0x36, 0xa5, 0xac, 0x50, 0xc9, 0xbf, 0xdf, 0x90, 0xec, 0x18, 0xce, 0xfc, 0xdd, 0xef,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x86, 0x77, 0xd0, 0x07, 0x42, 0x03, 0x00, 0x00,
+	// 867 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x95, 0xcf, 0x6f, 0xdb, 0x36,
+	0x14, 0xc7, 0xe3, 0x25, 0x69, 0x6c, 0x3a, 0xb6, 0x13, 0x36, 0x6d, 0x99, 0x14, 0xf3, 0x0c, 0x77,
+	0x3f, 0x8c, 0x21, 0xb1, 0x87, 0xe6, 0x30, 0x0c, 0x43, 0x31, 0x34, 0x49, 0x87, 0x04, 0xeb, 0x80,
+	0x40, 0x68, 0x37, 0x60, 0x17, 0x81, 0x26, 0x69, 0x89, 0xb0, 0x44, 0x0a, 0x24, 0x9d, 0xc6, 0xff,
+	0xc5, 0xfe, 0xa5, 0xdd, 0x7a, 0xec, 0x71, 0xc7, 0x2d, 0xb9, 0xed, 0xaf, 0x18, 0xf8, 0x28, 0x29,
+	0xce, 0x30, 0x6c, 0x37, 0xbd, 0xef, 0xfb, 0xbc, 0xaf, 0x28, 0xbe, 0x47, 0x0a, 0x3d, 0x33, 0x22,
+	0xa3, 0x4b, 0x61, 0x26, 0x2c, 0xa5, 0x52, 0xd9, 0x49, 0x4a, 0x4d, 0xae, 0xd5, 0x72, 0xc2, 0xb4,
+	0x9a, 0xc9, 0x64, 0x5c, 0x18, 0xed, 0x34, 0xfe, 0xb8, 0x84, 0xc6, 0x01, 0x1a, 0x97, 0xd0, 0x38,
+	0x40, 0x07, 0x7b, 0x89, 0x4e, 0x34, 0x90, 0x13, 0xff, 0x14, 0x8a, 0x86, 0x7f, 0x35, 0x51, 0xfb,
+	0xd4, 0xf3, 0xa7, 0x40, 0xe1, 0x7d, 0xd4, 0x84, 0xf2, 0x58, 0x72, 0xd2, 0x18, 0x34, 0x46, 0xad,
+	0x68, 0x0b, 0xe2, 0x0b, 0x8e, 0x47, 0x68, 0xa7, 0xb4, 0x8c, 0x6b, 0xe4, 0x23, 0x40, 0xba, 0xa5,
+	0x7e, 0x5a, 0x92, 0xfb, 0xa8, 0x69, 0x53, 0x6a, 0xb8, 0x27, 0xd6, 0x07, 0x8d, 0x51, 0x27, 0xda,
+	0x82, 0xf8, 0x82, 0xe3, 0x4f, 0x51, 0x37, 0xa4, 0x4c, 0xc1, 0x62, 0xca, 0xb9, 0x21, 0x1b, 0x60,
+	0xb1, 0x0d, 0x6a, 0x54, 0xb0, 0x97, 0x9c, 0x1b, 0xfc, 0x39, 0xea, 0x4d, 0x05, 0x65, 0x5a, 0xdd,
+	0x61, 0x9b, 0x80, 0x75, 0x82, 0x5c, 0x71, 0x5f, 0xa2, 0xdd, 0xe0, 0x56, 0x18, 0x79, 0x45, 0x9d,
+	0x88, 0xe7, 0x62, 0x49, 0x1e, 0x00, 0xd9, 0x83, 0xc4, 0x65, 0xd0, 0x7f, 0x10, 0x4b, 0x7c, 0x88,
+	0x70, 0xe9, 0xb9, 0x0a, 0x6f, 0x01, 0xbc, 0x13, 0x32, 0x2b, 0xf4, 0x08, 0xed, 0xc8, 0x29, 0x8b,
+	0x53, 0x6d, 0x1d, 0xbc, 0x5f, 0x58, 0x4b, 0x9a, 0xe1, 0x63, 0xe5, 0x94, 0x9d, 0x6b, 0xeb, 0x5e,
+	0x06, 0x15, 0x8f, 0xd1, 0x43, 0x20, 0xa9, 0xe2, 0x99, 0x30, 0x35, 0xdc, 0x02, 0x78, 0xd7, 0xc3,
+	0x21, 0x53, 0xf1, 0x87, 0x08, 0x4b, 0x66, 0x9f, 0x7f, 0x15, 0x4f, 0xa9, 0x9a, 0xd7, 0x38, 0x0a,
+	0xeb, 0x80, 0xcc, 0x09, 0x55, 0xf3, 0x8a, 0x7e, 0x81, 0x9e, 0x06, 0xda, 0x19, 0xaa, 0xec, 0x4c,
+	0x98, 0xfb, 0x65, 0x6d, 0x28, 0x23, 0x80, 0xbc, 0x29, 0x89, 0xd5, 0xf2, 0x67, 0xa8, 0xe3, 0xf4,
+	0x5c, 0xa8, 0xba, 0x60, 0x3b, 0xec, 0x36, 0x88, 0x15, 0xf4, 0x14, 0xb5, 0x12, 0x6a, 0xe3, 0x4c,
+	0xe6, 0xd2, 0x91, 0xce, 0xa0, 0x31, 0xda, 0x88, 0x9a, 0x09, 0xb5, 0xaf, 0x7d, 0x5c, 0x25, 0x0b,
+	0x23, 0x99, 0x20, 0xdd, 0x41, 0x63, 0xb4, 0x0e, 0xc9, 0x4b, 0x1f, 0xe3, 0xaf, 0x11, 0xb9, 0xeb,
+	0xe6, 0x8c, 0x66, 0xd9, 0x94, 0xb2, 0xb0, 0x38, 0x4b, 0x7a, 0x83, 0xf5, 0x51, 0x2b, 0x7a, 0x54,
+	0xf5, 0xf5, 0xfb, 0x32, 0xeb, 0x5f, 0x6a, 0xf1, 0x37, 0x68, 0x7f, 0xa5, 0xc1, 0xff, 0xa8, 0xdc,
+	0x81, 0xca, 0xc7, 0x75, 0xab, 0xef, 0x97, 0x0e, 0x51, 0x27, 0xa7, 0xd7, 0xf1, 0x34, 0xd3, 0x6c,
+	0x1e, 0x67, 0x34, 0x21, 0xbb, 0xb0, 0xe2, 0x76, 0x4e, 0xaf, 0x4f, 0xbc, 0xf6, 0x9a, 0x26, 0x7e,
+	0x7e, 0xbc, 0xaf, 0xe7, 0x8c, 0x70, 0x46, 0x0a, 0x4b, 0x30, 0xcc, 0x61, 0xc7, 0x14, 0xec, 0x47,
+	0x7a, 0x1d, 0x05, 0x11, 0x7f, 0x86, 0xba, 0x85, 0x59, 0x28, 0xa9, 0x92, 0xf8, 0x9d, 0x54, 0x5c,
+	0xbf, 0x23, 0x0f, 0xc1, 0xac, 0x53, 0xaa, 0x3f, 0x83, 0x88, 0x3f, 0x41, 0x6d, 0x6f, 0xe7, 0x64,
+	0x2e, 0xf4, 0xc2, 0x91, 0x3d, 0xd8, 0x43, 0x64, 0x0a, 0xf6, 0x26, 0x28, 0xf8, 0x2d, 0xea, 0xa5,
+	0x82, 0x72, 0x61, 0xe2, 0x2b, 0x61, 0xac, 0xd4, 0xca, 0x92, 0x47, 0x83, 0xf5, 0x51, 0xfb, 0xf9,
+	0xe1, 0xf8, 0x3f, 0x0f, 0xe5, 0xf8, 0x1c, 0xaa, 0x7e, 0x0a, 0x45, 0x51, 0x37, 0x5d, 0x0d, 0xe1,
+	0x53, 0xeb, 0xc6, 0xc4, 0x46, 0xcc, 0xc8, 0x63, 0x78, 0x73, 0xbb, 0x6a, 0x4e, 0x24, 0x66, 0x15,
+	0x03, 0xfd, 0x01, 0xe6, 0x49, 0xcd, 0x40, 0x8f, 0x3c, 0x73, 0x81, 0xda, 0x5c, 0x28, 0x9d, 0xc7,
+	0x0b, 0x25, 0x9d, 0x25, 0x04, 0x96, 0x36, 0xfa, 0x9f, 0xa5, 0x9d, 0xf9, 0x8a, 0xb7, 0x4a, 0xba,
+	0x08, 0xf1, 0xea, 0xd1, 0xe2, 0x6f, 0xd1, 0x41, 0x7d, 0x2e, 0xb8, 0x28, 0x32, 0xbd, 0xcc, 0x85,
+	0x72, 0x71, 0x2a, 0x64, 0x92, 0x3a, 0xb2, 0x0f, 0xbb, 0xf7, 0xa4, 0x3c, 0x21, 0x67, 0x75, 0xfe,
+	0x1c, 0xd2, 0xc3, 0x17, 0xa8, 0x55, 0xbb, 0xe2, 0x3d, 0xb4, 0x09, 0xbe, 0xe5, 0x35, 0x13, 0x02,
+	0x7c, 0x80, 0x9a, 0x5c, 0x30, 0x99, 0xd3, 0xcc, 0xc2, 0xe5, 0xd2, 0x89, 0xea, 0x78, 0xf8, 0x1d,
+	0xea, 0xdc, 0xdb, 0x2f, 0x6f, 0x21, 0x0a, 0xcd, 0x52, 0xb0, 0xd8, 0x88, 0x42, 0x80, 0x09, 0xda,
+	0x2a, 0xbb, 0x50, 0x5e, 0x4f, 0x55, 0x38, 0xfc, 0xad, 0x81, 0xb6, 0x2f, 0x8d, 0xbe, 0x12, 0xa6,
+	0xbc, 0xed, 0xbe, 0x40, 0x3d, 0x67, 0x16, 0xd6, 0xf9, 0x01, 0x28, 0x84, 0x91, 0xba, 0xba, 0xf4,
+	0xba, 0x95, 0x7c, 0x09, 0xaa, 0x1f, 0x28, 0x3f, 0x4c, 0x0c, 0x86, 0x8e, 0x1b, 0x39, 0x73, 0xa5,
+	0xb7, 0x9f, 0xc5, 0x53, 0xaf, 0x9e, 0x79, 0xd1, 0xaf, 0xc8, 0x19, 0xca, 0xe6, 0x70, 0xed, 0xb5,
+	0xa2, 0x10, 0xf8, 0x31, 0x93, 0x4a, 0x3a, 0x49, 0xb3, 0x6a, 0xa3, 0x36, 0xc2, 0x98, 0x95, 0x6a,
+	0xd8, 0x1e, 0x7f, 0x58, 0x2b, 0x2c, 0x7c, 0xd6, 0x26, 0x50, 0xdb, 0xa5, 0xf8, 0xca, 0x6b, 0x27,
+	0xec, 0xfd, 0x9f, 0xfd, 0xb5, 0xf7, 0x37, 0xfd, 0xc6, 0x87, 0x9b, 0x7e, 0xe3, 0x8f, 0x9b, 0x7e,
+	0xe3, 0xd7, 0xdb, 0xfe, 0xda, 0x87, 0xdb, 0xfe, 0xda, 0xef, 0xb7, 0xfd, 0xb5, 0x5f, 0x5e, 0x25,
+	0xd2, 0xa5, 0x8b, 0xe9, 0x98, 0xe9, 0x7c, 0x92, 0xd3, 0x82, 0x8b, 0xab, 0xe3, 0xe3, 0xea, 0x6f,
+	0x71, 0xc4, 0xb4, 0xcd, 0xb5, 0x3d, 0x9a, 0x1a, 0xc9, 0x13, 0x71, 0xc4, 0x45, 0xae, 0x27, 0xff,
+	0xfe, 0x5f, 0x99, 0x3e, 0x80, 0x9f, 0xc3, 0xf1, 0xdf, 0x03, 0x00, 0x7a, 0xde, 0x98, 0x11, 0x78,
+	0x06, 0x00, 0x00,
 }
 
 func (m *ChainConfig) Marshal() (dAtA []byte, err error) {
@@ -182,6 +323,113 @@ func (m *ChainConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.IbcHostDeploymentHeight != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.IbcHostDeploymentHeight))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc8
+	}
+	if len(m.DenomUnits) > 0 {
+		for iNdEx := len(m.DenomUnits) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DenomUnits[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintConfig(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xc2
+		}
+	}
+	if len(m.GasPriceRef) > 0 {
+		i -= len(m.GasPriceRef)
+		copy(dAtA[i:], m.GasPriceRef)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.GasPriceRef)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xba
+	}
+	if len(m.GasLimitRef) > 0 {
+		i -= len(m.GasLimitRef)
+		copy(dAtA[i:], m.GasLimitRef)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.GasLimitRef)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb2
+	}
+	if len(m.HeaderVersions) > 0 {
+		for iNdEx := len(m.HeaderVersions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.HeaderVersions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintConfig(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xaa
+		}
+	}
+	if len(m.RpcTimeout) > 0 {
+		i -= len(m.RpcTimeout)
+		copy(dAtA[i:], m.RpcTimeout)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.RpcTimeout)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
+	if m.PruningWindow != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.PruningWindow))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.RpcMaxRetries != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.RpcMaxRetries))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.MaxBlockLag != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.MaxBlockLag))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	if len(m.BeaconRpcFallbackAddrs) > 0 {
+		for iNdEx := len(m.BeaconRpcFallbackAddrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.BeaconRpcFallbackAddrs[iNdEx])
+			copy(dAtA[i:], m.BeaconRpcFallbackAddrs[iNdEx])
+			i = encodeVarintConfig(dAtA, i, uint64(len(m.BeaconRpcFallbackAddrs[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x82
+		}
+	}
+	if len(m.ShardRpcFallbackAddrs) > 0 {
+		for iNdEx := len(m.ShardRpcFallbackAddrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ShardRpcFallbackAddrs[iNdEx])
+			copy(dAtA[i:], m.ShardRpcFallbackAddrs[iNdEx])
+			i = encodeVarintConfig(dAtA, i, uint64(len(m.ShardRpcFallbackAddrs[iNdEx])))
+			i--
+			dAtA[i] = 0x7a
+		}
+	}
 	if m.GasPrice != 0 {
 		i = encodeVarintConfig(dAtA, i, uint64(m.GasPrice))
 		i--
@@ -277,6 +525,76 @@ func (m *ChainConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *DenomUnit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DenomUnit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DenomUnit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Decimals != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.Decimals))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Denom) > 0 {
+		i -= len(m.Denom)
+		copy(dAtA[i:], m.Denom)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Denom)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HeaderVersion) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HeaderVersion) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HeaderVersion) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Version) > 0 {
+		i -= len(m.Version)
+		copy(dAtA[i:], m.Version)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Version)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Epoch != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.Epoch))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ProverConfig) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -297,6 +615,30 @@ func (m *ProverConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.InitialEpoch != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.InitialEpoch))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.InitialHeight != 0 {
+		i = encodeVarintConfig(dAtA, i, uint64(m.InitialHeight))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Track) > 0 {
+		i -= len(m.Track)
+		copy(dAtA[i:], m.Track)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.Track)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.MaxClockDrift) > 0 {
+		i -= len(m.MaxClockDrift)
+		copy(dAtA[i:], m.MaxClockDrift)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.MaxClockDrift)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.TrustingPeriod) > 0 {
 		i -= len(m.TrustingPeriod)
 		copy(dAtA[i:], m.TrustingPeriod)
@@ -377,6 +719,86 @@ func (m *ChainConfig) Size() (n int) {
 	if m.GasPrice != 0 {
 		n += 1 + sovConfig(uint64(m.GasPrice))
 	}
+	if len(m.ShardRpcFallbackAddrs) > 0 {
+		for _, s := range m.ShardRpcFallbackAddrs {
+			l = len(s)
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
+	if len(m.BeaconRpcFallbackAddrs) > 0 {
+		for _, s := range m.BeaconRpcFallbackAddrs {
+			l = len(s)
+			n += 2 + l + sovConfig(uint64(l))
+		}
+	}
+	if m.MaxBlockLag != 0 {
+		n += 2 + sovConfig(uint64(m.MaxBlockLag))
+	}
+	if m.RpcMaxRetries != 0 {
+		n += 2 + sovConfig(uint64(m.RpcMaxRetries))
+	}
+	if m.PruningWindow != 0 {
+		n += 2 + sovConfig(uint64(m.PruningWindow))
+	}
+	l = len(m.RpcTimeout)
+	if l > 0 {
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	if len(m.HeaderVersions) > 0 {
+		for _, e := range m.HeaderVersions {
+			l = e.Size()
+			n += 2 + l + sovConfig(uint64(l))
+		}
+	}
+	l = len(m.GasLimitRef)
+	if l > 0 {
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	l = len(m.GasPriceRef)
+	if l > 0 {
+		n += 2 + l + sovConfig(uint64(l))
+	}
+	if len(m.DenomUnits) > 0 {
+		for _, e := range m.DenomUnits {
+			l = e.Size()
+			n += 2 + l + sovConfig(uint64(l))
+		}
+	}
+	if m.IbcHostDeploymentHeight != 0 {
+		n += 2 + sovConfig(uint64(m.IbcHostDeploymentHeight))
+	}
+	return n
+}
+
+func (m *DenomUnit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Denom)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.Decimals != 0 {
+		n += 1 + sovConfig(uint64(m.Decimals))
+	}
+	return n
+}
+
+func (m *HeaderVersion) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Epoch != 0 {
+		n += 1 + sovConfig(uint64(m.Epoch))
+	}
+	l = len(m.Version)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
 	return n
 }
 
@@ -390,6 +812,20 @@ func (m *ProverConfig) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovConfig(uint64(l))
 	}
+	l = len(m.MaxClockDrift)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = len(m.Track)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if m.InitialHeight != 0 {
+		n += 1 + sovConfig(uint64(m.InitialHeight))
+	}
+	if m.InitialEpoch != 0 {
+		n += 1 + sovConfig(uint64(m.InitialEpoch))
+	}
 	return n
 }
 
@@ -837,62 +1273,574 @@ func (m *ChainConfig) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipConfig(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardRpcFallbackAddrs", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthConfig
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthConfig
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ProverConfig) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowConfig
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ProverConfig: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProverConfig: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.ShardRpcFallbackAddrs = append(m.ShardRpcFallbackAddrs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 16:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TrustingPeriod", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BeaconRpcFallbackAddrs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BeaconRpcFallbackAddrs = append(m.BeaconRpcFallbackAddrs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxBlockLag", wireType)
+			}
+			m.MaxBlockLag = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxBlockLag |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RpcMaxRetries", wireType)
+			}
+			m.RpcMaxRetries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RpcMaxRetries |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PruningWindow", wireType)
+			}
+			m.PruningWindow = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PruningWindow |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RpcTimeout", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RpcTimeout = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeaderVersions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.HeaderVersions = append(m.HeaderVersions, &HeaderVersion{})
+			if err := m.HeaderVersions[len(m.HeaderVersions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasLimitRef", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GasLimitRef = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasPriceRef", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GasPriceRef = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DenomUnits", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DenomUnits = append(m.DenomUnits, &DenomUnit{})
+			if err := m.DenomUnits[len(m.DenomUnits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 25:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IbcHostDeploymentHeight", wireType)
+			}
+			m.IbcHostDeploymentHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IbcHostDeploymentHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DenomUnit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DenomUnit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DenomUnit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Denom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Decimals", wireType)
+			}
+			m.Decimals = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Decimals |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HeaderVersion) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HeaderVersion: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HeaderVersion: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Epoch", wireType)
+			}
+			m.Epoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Epoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConfig(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProverConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConfig
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProverConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProverConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrustingPeriod", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -922,6 +1870,108 @@ func (m *ProverConfig) Unmarshal(dAtA []byte) error {
 			}
 			m.TrustingPeriod = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxClockDrift", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MaxClockDrift = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Track", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Track = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitialHeight", wireType)
+			}
+			m.InitialHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InitialHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InitialEpoch", wireType)
+			}
+			m.InitialEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InitialEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -0,0 +1,109 @@
+package harmony
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestChainConfigResolve(t *testing.T) {
+	const key = "1f84c95ac16e6a50f08d44c7bde7aff8742212fda6e4321fde48bf83bef266dc"
+	keyFile := filepath.Join(t.TempDir(), "key")
+	if err := ioutil.WriteFile(keyFile, []byte(key+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("TEST_HARMONY_HOST", "localhost")
+	os.Setenv("TEST_HARMONY_GAS_LIMIT", "5000000")
+	defer os.Unsetenv("TEST_HARMONY_HOST")
+	defer os.Unsetenv("TEST_HARMONY_GAS_LIMIT")
+
+	raw := ChainConfig{
+		ShardRpcAddr:          "http://${TEST_HARMONY_HOST}:9598",
+		ShardRpcFallbackAddrs: []string{"http://${TEST_HARMONY_HOST}:9599"},
+		ShardPrivateKey:       "file://" + keyFile,
+		BeaconPrivateKey:      key,
+		GasLimit:              1,
+		GasLimitRef:           "${TEST_HARMONY_GAS_LIMIT}",
+		GasPrice:              100,
+	}
+	c, err := raw.Resolve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.ShardRpcAddr != "http://localhost:9598" || c.ShardRpcFallbackAddrs[0] != "http://localhost:9599" {
+		t.Errorf("unexpected endpoints: %v, %v", c.ShardRpcAddr, c.ShardRpcFallbackAddrs)
+	}
+	if c.ShardPrivateKey != key || c.BeaconPrivateKey != key {
+		t.Errorf("unexpected keys: %q, %q", c.ShardPrivateKey, c.BeaconPrivateKey)
+	}
+	if c.GasLimit != 5000000 || c.GasPrice != 100 {
+		t.Errorf("unexpected gas settings: %v, %v", c.GasLimit, c.GasPrice)
+	}
+	// the raw config keeps the references
+	if raw.ShardRpcFallbackAddrs[0] != "http://${TEST_HARMONY_HOST}:9599" || raw.ShardPrivateKey != "file://"+keyFile {
+		t.Errorf("the raw config is modified: %+v", raw)
+	}
+
+	raw.BeaconRpcAddr = "${TEST_HARMONY_UNSET}"
+	if _, err := raw.Resolve(); err == nil || !strings.Contains(err.Error(), "TEST_HARMONY_UNSET") {
+		t.Errorf("unexpected error for an unset variable: %v", err)
+	}
+	os.Setenv("TEST_HARMONY_GAS_LIMIT", key)
+	raw.BeaconRpcAddr = ""
+	if _, err := raw.Resolve(); err == nil || strings.Contains(err.Error(), key) {
+		t.Errorf("unexpected error for an invalid gas limit: %v", err)
+	}
+}
+
+func TestLogsStartHeight(t *testing.T) {
+	cases := []struct {
+		chainID string
+		height  uint64
+		ok      bool
+	}{
+		{"localnet", 0, true},
+		{"localnet", 100, true},
+		{"testnet", 100, true},
+		{"testnet", 0, false},
+		{"mainnet", 0, false},
+	}
+	for _, c := range cases {
+		height, err := ChainConfig{HarmonyChainId: c.chainID, IbcHostDeploymentHeight: c.height}.LogsStartHeight()
+		if c.ok && (err != nil || height != c.height) {
+			t.Errorf("LogsStartHeight() on %s with %d = %d, %v", c.chainID, c.height, height, err)
+		} else if !c.ok && err == nil {
+			t.Errorf("LogsStartHeight() on %s with %d must fail", c.chainID, c.height)
+		}
+	}
+}
+
+func TestProverConfigTrack(t *testing.T) {
+	cases := []struct {
+		track   string
+		shardId uint32
+		ok      bool
+		beacon  bool
+	}{
+		{"", 0, true, true},
+		{"", 1, true, false},
+		{TrackBeacon, 0, true, true},
+		{TrackShard, 1, true, false},
+		// the beacon chain has no crosslinks of its own, and other shards need shard headers for their state
+		{TrackBeacon, 1, false, false},
+		{TrackShard, 0, false, false},
+		{"unknown", 0, false, false},
+	}
+	for _, c := range cases {
+		config := ProverConfig{TrustingPeriod: "24h", Track: c.track}
+		err := config.Validate(c.shardId)
+		if c.ok != (err == nil) {
+			t.Errorf("Validate(%d) with track %q = %v", c.shardId, c.track, err)
+			continue
+		}
+		if c.ok && config.TracksBeacon(c.shardId) != c.beacon {
+			t.Errorf("TracksBeacon(%d) with track %q must be %v", c.shardId, c.track, c.beacon)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package harmony
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"math/big"
 	"strings"
@@ -9,34 +11,114 @@ import (
 	transfertypes "github.com/cosmos/ibc-go/modules/apps/transfer/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/harmony-one/go-sdk/pkg/transaction"
+	"github.com/harmony-one/harmony/accounts/abi"
 	harmonytypes "github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/numeric"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/simpletoken"
 )
 
 const (
 	msgTxMsgTransfer = "sendTransfer"
+
+	methodApprove  = "approve"
+	methodDeposit  = "deposit"
+	methodWithdraw = "withdraw"
 )
 
 func (c *Chain) QueryTokenBalance(address common.Address) (*big.Int, error) {
 	return c.simpleToken.BalanceOf(c.CallOpts(context.Background(), -1), address)
 }
 
+// QueryERC20Balance returns the balance of an ERC20 token, which isn't limited to token_address
+func (c *Chain) QueryERC20Balance(token, address common.Address) (*big.Int, error) {
+	ethClient, err := c.client.ETHClient()
+	if err != nil {
+		return nil, err
+	}
+	// SimpleToken is an ERC20 token, so its binding works for any ERC20 token
+	erc20, err := simpletoken.NewSimpletoken(token, ethClient)
+	if err != nil {
+		return nil, err
+	}
+	return erc20.BalanceOf(c.CallOpts(context.Background(), -1), address)
+}
+
 func (c *Chain) QueryBankBalance(address common.Address, id string) (*big.Int, error) {
 	idLower := strings.ToLower(id)
 	return c.ics20Bank.BalanceOf(c.CallOpts(context.Background(), -1), address, idLower)
 }
 
+// BankDenom returns the ID of the balances of an ERC20 token deposited into ICS20Bank,
+// which is the lower case hex address of the token
+func BankDenom(token common.Address) string {
+	return strings.ToLower(token.Hex())
+}
+
+// RelayerAddress returns the address of the relayer key
+func (c *Chain) RelayerAddress() (common.Address, error) {
+	account, err := c.getAccount()
+	if err != nil {
+		return common.Address{}, err
+	}
+	return account.Address, nil
+}
+
+// TxDeposit approves ICS20Bank to spend the amount of an ERC20 token of the relayer,
+// and then deposits it into ICS20Bank for the receiver. It returns the hash of the deposit.
+func (c *Chain) TxDeposit(token common.Address, amount *big.Int, receiver common.Address) (common.Hash, error) {
+	if _, err := c.txAndWait(token.Hex(), &c.simpleTokenAbi, methodApprove, c.config.ICS20BankAddress(), amount); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to approve ICS20Bank: %w", err)
+	}
+	receipt, err := c.txAndWait(c.config.Ics20BankAddress, &c.ics20BankAbi, methodDeposit, token, amount, receiver)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to deposit: %w", err)
+	}
+	return receipt.TxHash, nil
+}
+
+// TxWithdraw withdraws the amount of an ERC20 token of the relayer from ICS20Bank to the receiver.
+// It returns the hash of the withdrawal.
+func (c *Chain) TxWithdraw(token common.Address, amount *big.Int, receiver common.Address) (common.Hash, error) {
+	receipt, err := c.txAndWait(c.config.Ics20BankAddress, &c.ics20BankAbi, methodWithdraw, token, amount, receiver)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to withdraw: %w", err)
+	}
+	return receipt.TxHash, nil
+}
+
+// txAndWait sends a transaction and waits for it to succeed
+func (c *Chain) txAndWait(to string, abi *abi.ABI, method string, params ...interface{}) (*Receipt, error) {
+	tx, err := c.tx(to, abi, method, params...)
+	if err != nil {
+		return nil, err
+	}
+	return c.waitForReceipt(context.Background(), tx.Hash())
+}
+
 func (c *Chain) TxMsgTransfer(msg *transfertypes.MsgTransfer) (*harmonytypes.Transaction, error) {
 	denomLower := strings.ToLower(msg.Token.Denom)
-	return c.txIcs20TransferBank(
-		msgTxMsgTransfer,
+	method, ok := c.ics20TransferBankAbi.Methods[msgTxMsgTransfer]
+	if !ok {
+		return nil, fmt.Errorf("method %s not found in ICS20TransferBank", msgTxMsgTransfer)
+	}
+	// the amount is packed in the type of the contract, which may be narrower than uint256
+	amount, err := packableAmount(method.Inputs[1], msg.Token.Amount.BigInt())
+	if err != nil {
+		return nil, err
+	}
+	// the deployed ICS20TransferBank only takes a timeout height
+	if msg.TimeoutTimestamp != 0 {
+		return nil, errors.New("ICS20TransferBank doesn't take a timeout timestamp")
+	}
+	params := []interface{}{
 		denomLower,
-		msg.Token.Amount.Uint64(),
+		amount,
 		common.HexToAddress(msg.Receiver),
 		msg.SourcePort,
 		msg.SourceChannel,
 		msg.TimeoutHeight.RevisionHeight,
-	)
+	}
+	return c.txIcs20TransferBank(msgTxMsgTransfer, params...)
 }
 
 func (c *Chain) txIcs20TransferBank(method string, params ...interface{}) (*harmonytypes.Transaction, error) {
@@ -52,15 +134,16 @@ func (c *Chain) txIcs20TransferBank(method string, params ...interface{}) (*harm
 	if err = c.keyStore.Unlock(account, ""); err != nil {
 		return nil, err
 	}
-	controller := transaction.NewController(c.client.messenger, c.keyStore, &account, *c.chainId)
-	nonce := transaction.GetNextPendingNonce(account.Address.Hex(), c.client.messenger)
+	messenger := c.client.messenger(context.Background())
+	controller := transaction.NewController(messenger, c.keyStore, &account, *c.chainId)
+	nonce := transaction.GetNextPendingNonce(account.Address.Hex(), messenger)
 	err = controller.ExecuteTransaction(nonce, c.config.GasLimit, &c.config.Ics20TransferBankAddress, c.config.ShardId, c.config.ShardId, numeric.NewDec(0), c.config.GasPriceDec(), input)
 	if err != nil {
 		log.Println("config.GasLimit", c.config.GasLimit)
 		return nil, err
 	}
 	if err = c.keyStore.Lock(account.Address); err != nil {
-		panic(err)
+		return nil, err
 	}
 	return controller.TransactionInfo(), nil
 }
@@ -0,0 +1,387 @@
+package harmony
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/ibchandler"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/ibchost"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/ics20bank"
+)
+
+const (
+	PortTransfer              = "transfer"
+	TendermintLightClientType = "07-tendermint"
+
+	// the supply of SimpleToken minted to the deployer, as the truffle migration does
+	simpleTokenSupply = 1000000
+)
+
+// libraries are linked into the contracts which use them, and deployed in this order
+var libraries = []string{
+	"IBCIdentifier",
+	"IBCMsgs",
+	"IBCClient",
+	"IBCConnection",
+	"IBCChannel",
+	"Identifier",
+	"Bytes",
+}
+
+// DeployedContracts are the names of the contracts deployed by Deployer, including the libraries
+var DeployedContracts = append(append([]string{}, libraries...),
+	"TendermintLightClient",
+	"IBCHost",
+	"IBCHandler",
+	"SimpleToken",
+	"ICS20Bank",
+	"ICS20TransferBank",
+)
+
+// ContractArtifact is a compiled contract in the format of truffle build artifacts.
+// The Go bindings of the contracts don't contain their bytecode, so it is taken from the artifacts.
+type ContractArtifact struct {
+	ContractName string          `json:"contractName"`
+	ABI          json.RawMessage `json:"abi"`
+	// hex encoded creation bytecode, which may contain placeholders of libraries
+	Bytecode string `json:"bytecode"`
+	// the deployments by truffle migrations, keyed by network ID
+	Networks map[string]struct {
+		Address         string `json:"address"`
+		TransactionHash string `json:"transactionHash"`
+	} `json:"networks"`
+}
+
+// LoadContractArtifacts loads the artifacts of the deployed contracts from a truffle build directory
+func LoadContractArtifacts(dir string) (map[string]*ContractArtifact, error) {
+	artifacts := make(map[string]*ContractArtifact, len(DeployedContracts))
+	for _, name := range DeployedContracts {
+		artifact, err := loadContractArtifact(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		artifacts[name] = artifact
+	}
+	return artifacts, nil
+}
+
+func loadContractArtifact(dir string, name string) (*ContractArtifact, error) {
+	bz, err := ioutil.ReadFile(filepath.Join(dir, name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the artifact of %s: %w", name, err)
+	}
+	var artifact ContractArtifact
+	if err := json.Unmarshal(bz, &artifact); err != nil {
+		return nil, fmt.Errorf("failed to parse the artifact of %s: %w", name, err)
+	}
+	return &artifact, nil
+}
+
+// LoadDeployment loads a deployment in JSON
+func LoadDeployment(path string) (*Deployment, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var d Deployment
+	if err := json.Unmarshal(bz, &d); err != nil {
+		return nil, fmt.Errorf("failed to parse the deployment: %w", err)
+	}
+	return &d, nil
+}
+
+// LoadDeploymentFromArtifacts loads the addresses of the contracts deployed by truffle migrations on the given network
+func LoadDeploymentFromArtifacts(dir string, networkID string) (*Deployment, error) {
+	var d Deployment
+	for _, c := range []struct {
+		name    string
+		address *common.Address
+	}{
+		{"IBCHost", &d.IBCHost},
+		{"IBCHandler", &d.IBCHandler},
+		{"TendermintLightClient", &d.TendermintLightClient},
+		{"SimpleToken", &d.SimpleToken},
+		{"ICS20Bank", &d.ICS20Bank},
+		{"ICS20TransferBank", &d.ICS20TransferBank},
+	} {
+		artifact, err := loadContractArtifact(dir, c.name)
+		if err != nil {
+			return nil, err
+		}
+		network, ok := artifact.Networks[networkID]
+		if !ok || !common.IsHexAddress(network.Address) {
+			return nil, fmt.Errorf("%s is not deployed on network %s", c.name, networkID)
+		}
+		*c.address = common.HexToAddress(network.Address)
+		if c.name == "IBCHost" && network.TransactionHash != "" {
+			txHash := common.HexToHash(network.TransactionHash)
+			d.IBCHostTxHash = &txHash
+		}
+	}
+	return &d, nil
+}
+
+// Deployment is the addresses of the contracts deployed by Deployer
+type Deployment struct {
+	IBCHost               common.Address `json:"ibc_host"`
+	IBCHandler            common.Address `json:"ibc_handler"`
+	TendermintLightClient common.Address `json:"tendermint_light_client"`
+	SimpleToken           common.Address `json:"simple_token"`
+	ICS20Bank             common.Address `json:"ics20_bank"`
+	ICS20TransferBank     common.Address `json:"ics20_transfer_bank"`
+	// the block at which IBCHost was deployed
+	IBCHostDeploymentHeight uint64 `json:"ibc_host_deployment_height,omitempty"`
+	// the transaction which deployed IBCHost, from which IBCHostDeploymentHeight is found if it's unknown
+	IBCHostTxHash *common.Hash `json:"ibc_host_tx_hash,omitempty"`
+}
+
+// ResolveDeploymentHeight sets IBCHostDeploymentHeight from the receipt of the deployment transaction of IBCHost
+// if the height is unknown and the transaction is known
+func (d *Deployment) ResolveDeploymentHeight(ctx context.Context, backend bind.DeployBackend) error {
+	if d.IBCHostDeploymentHeight != 0 || d.IBCHostTxHash == nil {
+		return nil
+	}
+	receipt, err := backend.TransactionReceipt(ctx, *d.IBCHostTxHash)
+	if err != nil {
+		return fmt.Errorf("failed to get the receipt of the deployment of IBCHost %s: %w", d.IBCHostTxHash.Hex(), err)
+	}
+	d.IBCHostDeploymentHeight = receipt.BlockNumber.Uint64()
+	return nil
+}
+
+// Apply sets the addresses and the deployment height of the deployment to the config
+func (d *Deployment) Apply(config *ChainConfig) {
+	config.IbcHostAddress = d.IBCHost.Hex()
+	config.IbcHandlerAddress = d.IBCHandler.Hex()
+	config.TokenAddress = d.SimpleToken.Hex()
+	config.Ics20BankAddress = d.ICS20Bank.Hex()
+	config.Ics20TransferBankAddress = d.ICS20TransferBank.Hex()
+	if d.IBCHostDeploymentHeight != 0 {
+		config.IbcHostDeploymentHeight = d.IBCHostDeploymentHeight
+	}
+}
+
+// DeployBackend is a backend to deploy contracts and wait for them to be mined
+type DeployBackend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+// Deployer deploys the IBC contracts and wires them up in the same way as the truffle migrations
+type Deployer struct {
+	backend   DeployBackend
+	opts      *bind.TransactOpts
+	artifacts map[string]*ContractArtifact
+	linked    map[string]common.Address
+	// the blocks at which the contracts were deployed
+	heights map[string]uint64
+}
+
+// NewDeployer returns a deployer which sends transactions with the given options
+func NewDeployer(backend DeployBackend, opts *bind.TransactOpts, artifacts map[string]*ContractArtifact) *Deployer {
+	return &Deployer{
+		backend:   backend,
+		opts:      opts,
+		artifacts: artifacts,
+		linked:    make(map[string]common.Address),
+		heights:   make(map[string]uint64),
+	}
+}
+
+// Deploy deploys the contracts, and then sets the IBC module of IBCHost, binds the transfer port,
+// registers the Tendermint light client and sets the operator of ICS20Bank
+func (d *Deployer) Deploy(ctx context.Context) (*Deployment, error) {
+	for _, name := range libraries {
+		addr, err := d.deploy(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		d.linked[name] = addr
+	}
+
+	var (
+		out Deployment
+		err error
+	)
+	if out.TendermintLightClient, err = d.deploy(ctx, "TendermintLightClient"); err != nil {
+		return nil, err
+	}
+	if out.IBCHost, err = d.deploy(ctx, "IBCHost"); err != nil {
+		return nil, err
+	}
+	out.IBCHostDeploymentHeight = d.heights["IBCHost"]
+	if out.IBCHandler, err = d.deploy(ctx, "IBCHandler", out.IBCHost); err != nil {
+		return nil, err
+	}
+	if out.SimpleToken, err = d.deploy(ctx, "SimpleToken", "simple", "simple", big.NewInt(simpleTokenSupply)); err != nil {
+		return nil, err
+	}
+	if out.ICS20Bank, err = d.deploy(ctx, "ICS20Bank"); err != nil {
+		return nil, err
+	}
+	if out.ICS20TransferBank, err = d.deploy(ctx, "ICS20TransferBank", out.IBCHost, out.IBCHandler, out.ICS20Bank); err != nil {
+		return nil, err
+	}
+
+	if err := d.wire(ctx, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (d *Deployer) wire(ctx context.Context, out *Deployment) error {
+	ibcHost, err := ibchost.NewIbchost(out.IBCHost, d.backend)
+	if err != nil {
+		return err
+	}
+	ibcHandler, err := ibchandler.NewIbchandler(out.IBCHandler, d.backend)
+	if err != nil {
+		return err
+	}
+	ics20Bank, err := ics20bank.NewIcs20bank(out.ICS20Bank, d.backend)
+	if err != nil {
+		return err
+	}
+	for _, step := range []struct {
+		name string
+		send func(opts *bind.TransactOpts) (*types.Transaction, error)
+	}{
+		{"setIBCModule", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return ibcHost.SetIBCModule(opts, out.IBCHandler)
+		}},
+		{"bindPort", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return ibcHandler.BindPort(opts, PortTransfer, out.ICS20TransferBank)
+		}},
+		{"registerClient", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return ibcHandler.RegisterClient(opts, TendermintLightClientType, out.TendermintLightClient)
+		}},
+		{"setOperator", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return ics20Bank.SetOperator(opts, out.ICS20TransferBank)
+		}},
+	} {
+		tx, err := step.send(d.transactOpts(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to send %s: %w", step.name, err)
+		}
+		receipt, err := bind.WaitMined(ctx, d.backend, tx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for %s: %w", step.name, err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			return fmt.Errorf("%s failed to execute: tx=%s", step.name, tx.Hash().Hex())
+		}
+	}
+	return nil
+}
+
+// deploy links the libraries deployed so far into the contract and deploys it
+func (d *Deployer) deploy(ctx context.Context, name string, params ...interface{}) (common.Address, error) {
+	artifact, ok := d.artifacts[name]
+	if !ok {
+		return common.Address{}, fmt.Errorf("no artifact of %s", name)
+	}
+	parsed, err := abi.JSON(strings.NewReader(string(artifact.ABI)))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid abi of %s: %w", name, err)
+	}
+	bytecode, err := linkBytecode(artifact.Bytecode, d.linked)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to link %s: %w", name, err)
+	}
+	addr, tx, _, err := bind.DeployContract(d.transactOpts(ctx), parsed, bytecode, d.backend, params...)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to deploy %s: %w", name, err)
+	}
+	receipt, err := bind.WaitMined(ctx, d.backend, tx)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to wait for the deployment of %s: %w", name, err)
+	}
+	// it fails if the contract has no code after the deployment
+	if _, err := bind.WaitDeployed(ctx, d.backend, tx); err != nil {
+		return common.Address{}, fmt.Errorf("failed to wait for the deployment of %s: %w", name, err)
+	}
+	d.heights[name] = receipt.BlockNumber.Uint64()
+	return addr, nil
+}
+
+func (d *Deployer) transactOpts(ctx context.Context) *bind.TransactOpts {
+	opts := *d.opts
+	opts.Context = ctx
+	return &opts
+}
+
+// linkBytecode replaces the placeholders of the libraries in a hex encoded bytecode with their addresses
+func linkBytecode(bytecode string, libs map[string]common.Address) ([]byte, error) {
+	code := strings.TrimPrefix(bytecode, "0x")
+	if code == "" {
+		return nil, errors.New("empty bytecode")
+	}
+	for name, addr := range libs {
+		code = strings.ReplaceAll(code, libraryPlaceholder(name), strings.ToLower(strings.TrimPrefix(addr.Hex(), "0x")))
+	}
+	if i := strings.Index(code, "__"); i >= 0 {
+		end := i + 40
+		if end > len(code) {
+			end = len(code)
+		}
+		return nil, fmt.Errorf("unlinked library %s", strings.Trim(code[i:end], "_"))
+	}
+	return common.FromHex(code), nil
+}
+
+// libraryPlaceholder returns the placeholder of a library in truffle artifacts,
+// which is the name of the library padded with underscores to the length of an address
+func libraryPlaceholder(name string) string {
+	p := "__" + name
+	if len(p) > 40 {
+		return p[:40]
+	}
+	return p + strings.Repeat("_", 40-len(p))
+}
+
+// NewTransactOpts returns the options to send Ethereum compatible transactions signed by the key
+func NewTransactOpts(key *ecdsa.PrivateKey, chainID *big.Int, gasLimit uint64, gasPrice *big.Int) *bind.TransactOpts {
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	signer := types.NewEIP155Signer(chainID)
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(_ types.Signer, addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if addr != from {
+				return nil, errors.New("not authorized to sign this account")
+			}
+			return types.SignTx(tx, signer, key)
+		},
+		GasLimit: gasLimit,
+		GasPrice: gasPrice,
+	}
+}
+
+// NewDeployer returns a deployer which sends transactions with the relayer key through the Ethereum compatible RPC of the shard
+func (c *Chain) NewDeployer(ctx context.Context, artifacts map[string]*ContractArtifact) (*Deployer, error) {
+	key, err := crypto.HexToECDSA(c.config.ShardPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	ethClient, err := c.client.ETHClient()
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := ethClient.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := NewTransactOpts(key, chainID, c.config.GasLimit, c.config.GasPriceWei())
+	return NewDeployer(ethClient, opts, artifacts), nil
+}
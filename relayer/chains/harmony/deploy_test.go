@@ -0,0 +1,229 @@
+package harmony
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/ibchandler"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/ibchost"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/ics20bank"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/ics20transferbank"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/simpletoken"
+)
+
+// recorderBytecode deploys a contract which accepts any call, and records it by storing 1 at the slot of keccak256(calldata).
+// The runtime code is CALLDATASIZE 0 0 CALLDATACOPY CALLDATASIZE 0 SHA3 1 SWAP1 SSTORE STOP.
+// The Go bindings don't contain the bytecode of the IBC contracts, so the recorders stand in for them
+// to check that each wiring transaction is executed on the right contract with the right arguments.
+const recorderBytecode = "0x600f600c600039600f6000f3" + "366000600037366000206001905500"
+
+// recorded returns whether the recorder at the address has been called with the input
+func recorded(t *testing.T, backend *backends.SimulatedBackend, addr common.Address, input []byte) bool {
+	v, err := backend.StorageAt(context.Background(), addr, crypto.Keccak256Hash(input), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return common.BytesToHash(v) == common.BigToHash(big.NewInt(1))
+}
+
+// committingBackend mines a block for each transaction, and records the transactions
+type committingBackend struct {
+	*backends.SimulatedBackend
+	txs []*types.Transaction
+}
+
+func (b *committingBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := b.SimulatedBackend.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	b.txs = append(b.txs, tx)
+	b.Commit()
+	return nil
+}
+
+func testArtifacts() map[string]*ContractArtifact {
+	abis := map[string]string{
+		"IBCHost":           ibchost.IbchostABI,
+		"IBCHandler":        ibchandler.IbchandlerABI,
+		"SimpleToken":       simpletoken.SimpletokenABI,
+		"ICS20Bank":         ics20bank.Ics20bankABI,
+		"ICS20TransferBank": ics20transferbank.Ics20transferbankABI,
+	}
+	artifacts := make(map[string]*ContractArtifact)
+	for _, name := range DeployedContracts {
+		a := &ContractArtifact{ContractName: name, ABI: []byte("[]"), Bytecode: recorderBytecode}
+		if s, ok := abis[name]; ok {
+			a.ABI = []byte(s)
+		}
+		artifacts[name] = a
+	}
+	// the placeholder follows the code, so it is linked but never executed
+	artifacts["IBCHost"].Bytecode += libraryPlaceholder("IBCIdentifier")
+	return artifacts
+}
+
+func TestDeploy(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{
+		from: {Balance: new(big.Int).Lsh(big.NewInt(1), 100)},
+	}, 10000000)
+	backend := &committingBackend{SimulatedBackend: sim}
+	opts := NewTransactOpts(key, params.AllEthashProtocolChanges.ChainID, 1000000, big.NewInt(1))
+
+	deployer := NewDeployer(backend, opts, testArtifacts())
+	out, err := deployer.Deploy(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[common.Address]bool{}
+	for _, addr := range []common.Address{out.IBCHost, out.IBCHandler, out.TendermintLightClient, out.SimpleToken, out.ICS20Bank, out.ICS20TransferBank} {
+		if addr == (common.Address{}) || seen[addr] {
+			t.Fatalf("unexpected addresses: %+v", out)
+		}
+		seen[addr] = true
+	}
+
+	// the last transactions wire up the contracts
+	if len(backend.txs) != len(DeployedContracts)+4 {
+		t.Fatalf("unexpected number of transactions: %d", len(backend.txs))
+	}
+	hostABI, _ := abi.JSON(strings.NewReader(ibchost.IbchostABI))
+	handlerABI, _ := abi.JSON(strings.NewReader(ibchandler.IbchandlerABI))
+	bankABI, _ := abi.JSON(strings.NewReader(ics20bank.Ics20bankABI))
+	wiring := backend.txs[len(DeployedContracts):]
+	for i, c := range []struct {
+		to     common.Address
+		abi    abi.ABI
+		method string
+		args   []interface{}
+	}{
+		{out.IBCHost, hostABI, "setIBCModule", []interface{}{out.IBCHandler}},
+		{out.IBCHandler, handlerABI, "bindPort", []interface{}{PortTransfer, out.ICS20TransferBank}},
+		{out.IBCHandler, handlerABI, "registerClient", []interface{}{TendermintLightClientType, out.TendermintLightClient}},
+		{out.ICS20Bank, bankABI, "setOperator", []interface{}{out.ICS20TransferBank}},
+	} {
+		input, err := c.abi.Pack(c.method, c.args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tx := wiring[i]; *tx.To() != c.to || !bytes.Equal(tx.Data(), input) {
+			t.Errorf("unexpected transaction %d: to=%s, data=%x", i, tx.To().Hex(), tx.Data())
+		}
+		if !recorded(t, sim, c.to, input) {
+			t.Errorf("%s is not executed on %s", c.method, c.to.Hex())
+		}
+	}
+
+	// the contracts are deployed with the addresses of the ones they depend on
+	for _, c := range []struct {
+		name string
+		args []common.Address
+	}{
+		{"IBCHandler", []common.Address{out.IBCHost}},
+		{"ICS20TransferBank", []common.Address{out.IBCHost, out.IBCHandler, out.ICS20Bank}},
+	} {
+		var encoded []byte
+		for _, addr := range c.args {
+			encoded = append(encoded, common.LeftPadBytes(addr.Bytes(), 32)...)
+		}
+		tx := backend.txs[indexOf(DeployedContracts, c.name)]
+		if !bytes.HasSuffix(tx.Data(), encoded) {
+			t.Errorf("%s is deployed with unexpected constructor arguments: %x", c.name, tx.Data())
+		}
+	}
+	tx := backend.txs[indexOf(DeployedContracts, "IBCHost")]
+	if !bytes.Contains(tx.Data(), deployer.linked["IBCIdentifier"].Bytes()) {
+		t.Error("IBCIdentifier is not linked into IBCHost")
+	}
+
+	receipt, err := backend.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.IBCHostDeploymentHeight == 0 || out.IBCHostDeploymentHeight != receipt.BlockNumber.Uint64() {
+		t.Errorf("unexpected deployment height of IBCHost: %d", out.IBCHostDeploymentHeight)
+	}
+
+	cfg := ChainConfig{}
+	out.Apply(&cfg)
+	if cfg.IBCHostAddress() != out.IBCHost || cfg.IBCHandlerAddress() != out.IBCHandler || cfg.ICS20BankAddress() != out.ICS20Bank {
+		t.Fatalf("unexpected config: %+v", cfg)
+	}
+	if cfg.IbcHostDeploymentHeight != out.IBCHostDeploymentHeight {
+		t.Fatalf("unexpected deployment height in config: %d", cfg.IbcHostDeploymentHeight)
+	}
+
+	// the height is resolved from the deployment transaction, e.g. of a truffle migration
+	resolved := &Deployment{IBCHostTxHash: &receipt.TxHash}
+	if err := resolved.ResolveDeploymentHeight(context.Background(), backend); err != nil {
+		t.Fatal(err)
+	}
+	if resolved.IBCHostDeploymentHeight != out.IBCHostDeploymentHeight {
+		t.Fatalf("unexpected resolved deployment height: %d", resolved.IBCHostDeploymentHeight)
+	}
+}
+
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestLinkBytecode(t *testing.T) {
+	lib := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	code, err := linkBytecode("0x60"+libraryPlaceholder("IBCMsgs")+"00", map[string]common.Address{"IBCMsgs": lib})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := append(append([]byte{0x60}, lib.Bytes()...), 0x00)
+	if string(code) != string(want) {
+		t.Fatalf("unexpected code: %x", code)
+	}
+	if _, err := linkBytecode("0x60"+libraryPlaceholder("IBCChannel"), nil); err == nil || !strings.Contains(err.Error(), "IBCChannel") {
+		t.Fatalf("unexpected error for an unlinked library: %v", err)
+	}
+}
+
+func TestLoadDeploymentFromArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	names := []string{"IBCHost", "IBCHandler", "TendermintLightClient", "SimpleToken", "ICS20Bank", "ICS20TransferBank"}
+	for i, name := range names {
+		artifact := fmt.Sprintf(`{"contractName":%q,"networks":{"2":{"address":"0x%040x","transactionHash":"0x%064x"}}}`, name, i+1, i+1)
+		if err := ioutil.WriteFile(filepath.Join(dir, name+".json"), []byte(artifact), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	d, err := LoadDeploymentFromArtifacts(dir, "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.IBCHost != common.BigToAddress(big.NewInt(1)) || d.ICS20TransferBank != common.BigToAddress(big.NewInt(6)) {
+		t.Fatalf("unexpected deployment: %+v", d)
+	}
+	if d.IBCHostTxHash == nil || *d.IBCHostTxHash != common.BigToHash(big.NewInt(1)) {
+		t.Fatalf("unexpected deployment transaction of IBCHost: %v", d.IBCHostTxHash)
+	}
+	if _, err := LoadDeploymentFromArtifacts(dir, "1"); err == nil {
+		t.Fatal("contracts not deployed on the network must be rejected")
+	}
+}
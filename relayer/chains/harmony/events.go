@@ -2,10 +2,13 @@ package harmony
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
+	"sync"
 
 	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
 	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
@@ -53,7 +56,6 @@ func (chain *Chain) findPacket(
 	sequence uint64,
 ) (*chantypes.Packet, error) {
 	query := ethereum.FilterQuery{
-		FromBlock: big.NewInt(0),
 		Addresses: []common.Address{
 			chain.config.IBCHandlerAddress(),
 		},
@@ -111,7 +113,6 @@ func (chain *Chain) getAllPackets(
 ) ([]*chantypes.Packet, error) {
 	var packets []*chantypes.Packet
 	query := ethereum.FilterQuery{
-		FromBlock: big.NewInt(0),
 		Addresses: []common.Address{
 			chain.config.IBCHandlerAddress(),
 		},
@@ -167,7 +168,6 @@ func (chain *Chain) findAcknowledgement(
 	sequence uint64,
 ) ([]byte, error) {
 	query := ethereum.FilterQuery{
-		FromBlock: big.NewInt(0),
 		Addresses: []common.Address{
 			chain.config.IBCHandlerAddress(),
 		},
@@ -216,7 +216,6 @@ func (chain *Chain) getAllAcknowledgements(
 ) ([]PacketAcknowledgement, error) {
 	var acks []PacketAcknowledgement
 	query := ethereum.FilterQuery{
-		FromBlock: big.NewInt(0),
 		Addresses: []common.Address{
 			chain.config.IBCHandlerAddress(),
 		},
@@ -253,39 +252,116 @@ func (chain *Chain) getAllAcknowledgements(
 }
 
 func (chain *Chain) findLogsData(ctx context.Context, q ethereum.FilterQuery) ([][]byte, error) {
-	arg, err := toFilterArg(q)
+	logs, err := chain.findLogs(ctx, q)
 	if err != nil {
 		return nil, err
 	}
-	rep, err := chain.client.messenger.SendRPC(v1.Method.GetPastLogs, []interface{}{arg})
+	data := make([][]byte, len(logs))
+	for i, l := range logs {
+		data[i] = l.Data
+	}
+	return data, nil
+}
+
+// filterLog is a log returned by a filter query
+type filterLog struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	TxHash      common.Hash    `json:"transactionHash"`
+	Data        hexutil.Bytes  `json:"data"`
+}
+
+// logsPageSize is the number of blocks searched by a getLogs request, since Harmony nodes reject larger ranges
+var logsPageSize uint64 = 1024
+
+// logCursor is the logs found by a query so far, and the next block to search
+type logCursor struct {
+	next uint64
+	logs []filterLog
+}
+
+// logCursors keeps the logs found by each query, so that a query only searches the blocks added since the last one.
+// Harmony blocks are final once they are committed, so the found logs never change.
+type logCursors struct {
+	mu      sync.Mutex
+	cursors map[string]*logCursor
+}
+
+func newLogCursors() *logCursors {
+	return &logCursors{cursors: make(map[string]*logCursor)}
+}
+
+// findLogs returns the logs matching the addresses and topics of the query up to its ToBlock in the order of blocks.
+// ToBlock defaults to the latest block. The blocks from ibc_host_deployment_height are searched by pages of logsPageSize blocks,
+// and only the blocks which haven't been searched by the same query before are requested.
+func (chain *Chain) findLogs(ctx context.Context, q ethereum.FilterQuery) ([]filterLog, error) {
+	if q.FromBlock != nil || q.BlockHash != nil {
+		return nil, errors.New("findLogs searches the blocks from ibc_host_deployment_height, so FromBlock and BlockHash can't be given")
+	}
+	from, err := chain.config.LogsStartHeight()
 	if err != nil {
 		return nil, err
 	}
-	result, ok := rep["result"]
-	if !ok {
-		return nil, errors.New("invalid request")
+	var to uint64
+	if q.ToBlock != nil {
+		to = q.ToBlock.Uint64()
+	} else {
+		latest, err := chain.client.BlockNumber(ctx)
+		if err != nil {
+			return nil, err
+		}
+		to = latest
 	}
 
-	rs, ok := result.([]interface{})
+	chain.logCursors.mu.Lock()
+	defer chain.logCursors.mu.Unlock()
+	key := fmt.Sprintf("%v/%v/%d", q.Addresses, q.Topics, from)
+	cursor, ok := chain.logCursors.cursors[key]
 	if !ok {
-		return nil, errors.New("can't convert result to slice")
+		cursor = &logCursor{next: from}
+		chain.logCursors.cursors[key] = cursor
 	}
-
-	data := make([][]byte, len(rs))
-	for i, r := range rs {
-		resmap, ok := r.(map[string]interface{})
-		if !ok {
-			return nil, errors.New("can't convert to map")
-		}
-		dataStr, ok := resmap["data"].(string)
-		if !ok {
-			return nil, errors.New("can't convert data")
+	for cursor.next <= to {
+		end := cursor.next + logsPageSize - 1
+		if end > to {
+			end = to
 		}
-		bz, err := hexutil.Decode(dataStr)
+		page := q
+		page.FromBlock = new(big.Int).SetUint64(cursor.next)
+		page.ToBlock = new(big.Int).SetUint64(end)
+		logs, err := chain.getLogs(ctx, page)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to get logs of blocks %v-%v: %w", cursor.next, end, err)
 		}
-		data[i] = bz
+		cursor.logs = append(cursor.logs, logs...)
+		cursor.next = end + 1
 	}
-	return data, nil
+	// the logs after ToBlock may have been found by a previous query up to a later block
+	n := sort.Search(len(cursor.logs), func(i int) bool {
+		return uint64(cursor.logs[i].BlockNumber) > to
+	})
+	return cursor.logs[:n:n], nil
+}
+
+// getLogs returns the logs matching the query by a single request
+func (chain *Chain) getLogs(ctx context.Context, q ethereum.FilterQuery) ([]filterLog, error) {
+	arg, err := toFilterArg(q)
+	if err != nil {
+		return nil, err
+	}
+	result, err := chain.client.sendRPC(ctx, v1.Method.GetPastLogs, []interface{}{arg})
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := result.([]interface{}); !ok {
+		return nil, errors.New("can't convert result to slice")
+	}
+	bz, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var logs []filterLog
+	if err := json.Unmarshal(bz, &logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
 }
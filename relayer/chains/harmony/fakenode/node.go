@@ -0,0 +1,279 @@
+// Package fakenode provides an in-memory Harmony RPC node,
+// which serves enough of the Harmony and Ethereum compatible APIs to run the relayer without a network.
+package fakenode
+
+import (
+	"math/big"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/block"
+	v3 "github.com/harmony-one/harmony/block/v3"
+	hmytypes "github.com/harmony-one/harmony/core/types"
+)
+
+// CallHandler returns the output of a contract call with the given input
+type CallHandler func(input []byte) ([]byte, error)
+
+// Node is an in-memory Harmony RPC node of a single shard.
+// Blocks are produced only by MineBlock, and every block commits to the storage set so far.
+type Node struct {
+	mu sync.Mutex
+
+	shardID        uint32
+	blocksPerEpoch uint64
+	genesisTime    uint64
+
+	blocks   []*fakeBlock
+	storage  map[common.Address]map[common.Hash]common.Hash
+	calls    map[common.Address]CallHandler
+	logs     []*ethtypes.Log
+	txs      [][]byte
+	inputs   map[common.Hash][]byte
+	balances map[common.Address]*big.Int
+	chainID  uint64
+
+	// the number of recent blocks whose state is kept. 0 means an archive node
+	pruningWindow uint64
+	// the maximum number of blocks searched by a getLogs request. 0 means no limit
+	maxLogRange uint64
+	// the number of getLogs requests served
+	logRequests int
+
+	server *httptest.Server
+}
+
+type fakeBlock struct {
+	header  *v3.Header
+	storage map[common.Address]map[common.Hash]common.Hash
+}
+
+// New starts a node of the given shard with a genesis block
+func New(shardID uint32, blocksPerEpoch uint64) *Node {
+	if blocksPerEpoch == 0 {
+		panic("blocksPerEpoch must be greater than 0")
+	}
+	n := &Node{
+		shardID:        shardID,
+		blocksPerEpoch: blocksPerEpoch,
+		genesisTime:    1600000000,
+		storage:        make(map[common.Address]map[common.Hash]common.Hash),
+		calls:          make(map[common.Address]CallHandler),
+		inputs:         make(map[common.Hash][]byte),
+		balances:       make(map[common.Address]*big.Int),
+		chainID:        2, // localnet
+	}
+	n.MineBlock()
+	n.server = httptest.NewServer(n)
+	return n
+}
+
+// URL returns the endpoint of the node
+func (n *Node) URL() string {
+	return n.server.URL
+}
+
+// Close stops the node
+func (n *Node) Close() {
+	n.server.Close()
+}
+
+// SetStorage sets a storage slot of the given account, which is committed by the next block
+func (n *Node) SetStorage(address common.Address, slot, value common.Hash) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.storage[address]; !ok {
+		n.storage[address] = make(map[common.Hash]common.Hash)
+	}
+	n.storage[address][slot] = value
+}
+
+// SetPruningWindow makes the node discard the state of blocks older than the given number of recent blocks
+// as a non-archive node does. 0 makes the node an archive node.
+func (n *Node) SetPruningWindow(window uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.pruningWindow = window
+}
+
+// pruned returns true if the state at the given height has been discarded.
+// It must be called with the lock held.
+func (n *Node) pruned(number uint64) bool {
+	latest := uint64(len(n.blocks) - 1)
+	return n.pruningWindow > 0 && latest > n.pruningWindow && number < latest-n.pruningWindow
+}
+
+// SetMaxLogRange makes the node reject getLogs requests over more than the given number of blocks
+// as Harmony nodes do. 0 removes the limit.
+func (n *Node) SetMaxLogRange(blocks uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.maxLogRange = blocks
+}
+
+// LogRequests returns the number of getLogs requests served so far
+func (n *Node) LogRequests() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.logRequests
+}
+
+// SetBalance sets the balance of the given account
+func (n *Node) SetBalance(address common.Address, balance *big.Int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.balances[address] = new(big.Int).Set(balance)
+}
+
+// SetChainID sets the Harmony chain ID returned by the node, which defaults to the one of localnet
+func (n *Node) SetChainID(chainID uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.chainID = chainID
+}
+
+// HandleCall registers the handler of contract calls to the given address
+func (n *Node) HandleCall(address common.Address, handler CallHandler) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.calls[address] = handler
+}
+
+// AddLog adds a log to the next block
+func (n *Node) AddLog(address common.Address, topics []common.Hash, data []byte) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.addLog(common.Hash{}, address, topics, data)
+}
+
+// AddTransactionLog adds a transaction with the given input and a log emitted by it to the next block,
+// and returns the hash of the transaction
+func (n *Node) AddTransactionLog(input []byte, address common.Address, topics []common.Hash, data []byte) common.Hash {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	txHash := crypto.Keccak256Hash(input, big.NewInt(int64(len(n.logs))).Bytes())
+	n.inputs[txHash] = input
+	n.addLog(txHash, address, topics, data)
+	return txHash
+}
+
+func (n *Node) addLog(txHash common.Hash, address common.Address, topics []common.Hash, data []byte) {
+	n.logs = append(n.logs, &ethtypes.Log{
+		Address:     address,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: uint64(len(n.blocks)),
+		TxHash:      txHash,
+		Index:       uint(len(n.logs)),
+	})
+}
+
+// Transactions returns the raw transactions sent to the node
+func (n *Node) Transactions() [][]byte {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([][]byte{}, n.txs...)
+}
+
+// MineBlock produces a new block including the given cross links, and returns its header
+func (n *Node) MineBlock(crossLinks ...hmytypes.CrossLink) *v3.Header {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	number := uint64(len(n.blocks))
+	storage := copyStorage(n.storage)
+	root, err := stateRoot(storage)
+	if err != nil {
+		panic(err)
+	}
+
+	h := v3.NewHeader()
+	if number > 0 {
+		h.SetParentHash(hash(n.blocks[number-1].header))
+	}
+	h.SetRoot(root)
+	h.SetNumber(new(big.Int).SetUint64(number))
+	h.SetViewID(new(big.Int).SetUint64(number))
+	h.SetEpoch(new(big.Int).SetUint64(number / n.blocksPerEpoch))
+	h.SetShardID(n.shardID)
+	h.SetTime(new(big.Int).SetUint64(n.genesisTime + number*2))
+	h.SetGasLimit(80000000)
+	// the signature and the bitmap are never verified by the node
+	h.SetLastCommitSignature(commitSignature(number))
+	h.SetLastCommitBitmap([]byte{0xff})
+	if len(crossLinks) > 0 {
+		bz, err := rlp.EncodeToBytes(hmytypes.CrossLinks(crossLinks))
+		if err != nil {
+			panic(err)
+		}
+		h.SetCrossLinks(bz)
+	}
+
+	n.blocks = append(n.blocks, &fakeBlock{header: h, storage: storage})
+	return h
+}
+
+// CrossLink returns a cross link of the block at the given height
+func (n *Node) CrossLink(number uint64) hmytypes.CrossLink {
+	h := n.Header(number)
+	if h == nil {
+		panic("block not found")
+	}
+	return hmytypes.CrossLink{
+		HashF:        hash(h),
+		BlockNumberF: h.Number(),
+		ViewIDF:      h.ViewID(),
+		SignatureF:   commitSignature(number + 1),
+		BitmapF:      []byte{0xff},
+		ShardIDF:     h.ShardID(),
+		EpochF:       h.Epoch(),
+	}
+}
+
+// Header returns the header at the given height, or nil if it doesn't exist
+func (n *Node) Header(number uint64) *v3.Header {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if number >= uint64(len(n.blocks)) {
+		return nil
+	}
+	return n.blocks[number].header
+}
+
+// BlockNumber returns the latest block number
+func (n *Node) BlockNumber() uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return uint64(len(n.blocks) - 1)
+}
+
+// EpochLastBlockNumber returns the last block number of the given epoch
+func (n *Node) EpochLastBlockNumber(epoch uint64) uint64 {
+	return (epoch+1)*n.blocksPerEpoch - 1
+}
+
+func hash(h *v3.Header) common.Hash {
+	b := block.Header{Header: h}
+	return b.Hash()
+}
+
+func commitSignature(number uint64) [96]byte {
+	var sig [96]byte
+	copy(sig[:], crypto.Keccak256(new(big.Int).SetUint64(number).Bytes()))
+	return sig
+}
+
+func copyStorage(storage map[common.Address]map[common.Hash]common.Hash) map[common.Address]map[common.Hash]common.Hash {
+	res := make(map[common.Address]map[common.Hash]common.Hash, len(storage))
+	for addr, slots := range storage {
+		res[addr] = make(map[common.Hash]common.Hash, len(slots))
+		for k, v := range slots {
+			res[addr][k] = v
+		}
+	}
+	return res
+}
@@ -0,0 +1,372 @@
+package fakenode
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	v3 "github.com/harmony-one/harmony/block/v3"
+	rpcv2 "github.com/harmony-one/harmony/rpc/v2"
+)
+
+type rpcRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// ServeHTTP handles JSON-RPC requests, including batch requests
+func (n *Node) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if len(raw) > 0 && raw[0] == '[' {
+		var reqs []rpcRequest
+		if err := json.Unmarshal(raw, &reqs); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		res := make([]rpcResponse, len(reqs))
+		for i, req := range reqs {
+			res[i] = n.handle(req)
+		}
+		_ = json.NewEncoder(w).Encode(res)
+		return
+	}
+	var req rpcRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(n.handle(req))
+}
+
+func (n *Node) handle(req rpcRequest) rpcResponse {
+	res := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	result, err := n.dispatch(req.Method, req.Params)
+	if err != nil {
+		res.Error = &rpcError{Code: -32000, Message: err.Error()}
+	} else {
+		res.Result = result
+	}
+	return res
+}
+
+func (n *Node) dispatch(method string, params []json.RawMessage) (interface{}, error) {
+	switch method {
+	case "hmy_blockNumber", "hmyv2_blockNumber", "eth_blockNumber":
+		return hexutil.EncodeUint64(n.BlockNumber()), nil
+	case "hmy_getEpoch", "hmyv2_getEpoch":
+		return n.Header(n.BlockNumber()).Epoch().Uint64(), nil
+	case "hmyv2_epochLastBlock", "hmy_epochLastBlockNumber":
+		var epoch uint64
+		if err := unmarshalParam(params, 0, &epoch); err != nil {
+			return nil, err
+		}
+		return n.EpochLastBlockNumber(epoch), nil
+	case "hmyv2_getFullHeader":
+		number, err := n.blockNumberParam(params, 0)
+		if err != nil {
+			return nil, err
+		}
+		h := n.Header(number)
+		if h == nil {
+			return nil, fmt.Errorf("block %d not found", number)
+		}
+		return toRPCHeader(h), nil
+	case "eth_getProof", "hmy_getProof", "hmyv2_getProof":
+		return n.getProof(params)
+	case "eth_call", "hmy_call", "hmyv2_call":
+		return n.call(params)
+	case "eth_getBalance", "hmy_getBalance", "hmyv2_getBalance":
+		var address common.Address
+		if err := unmarshalParam(params, 0, &address); err != nil {
+			return nil, err
+		}
+		number, err := n.blockNumberParam(params, 1)
+		if err != nil {
+			return nil, err
+		}
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		if n.pruned(number) {
+			return nil, missingTrieNode(number)
+		}
+		if balance, ok := n.balances[address]; ok {
+			return (*hexutil.Big)(balance), nil
+		}
+		return hexutil.EncodeUint64(0), nil
+	case "hmy_chainId", "hmyv2_chainId":
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		return n.chainID, nil
+	case "eth_getCode", "hmy_getCode", "hmyv2_getCode":
+		var address common.Address
+		if err := unmarshalParam(params, 0, &address); err != nil {
+			return nil, err
+		}
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		if _, ok := n.calls[address]; ok {
+			return hexutil.Bytes{0x00}, nil
+		}
+		return hexutil.Bytes{}, nil
+	case "hmy_getLogs", "hmyv2_getLogs", "eth_getLogs":
+		return n.getLogs(params)
+	case "hmy_getTransactionByHash", "hmyv2_getTransactionByHash", "eth_getTransactionByHash":
+		var txHash common.Hash
+		if err := unmarshalParam(params, 0, &txHash); err != nil {
+			return nil, err
+		}
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		input, ok := n.inputs[txHash]
+		if !ok {
+			return nil, nil
+		}
+		return map[string]interface{}{"hash": txHash, "input": hexutil.Bytes(input)}, nil
+	case "hmy_getTransactionCount", "hmyv2_getTransactionCount", "eth_getTransactionCount":
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		return hexutil.EncodeUint64(uint64(len(n.txs))), nil
+	case "hmy_sendRawTransaction", "hmyv2_sendRawTransaction", "eth_sendRawTransaction":
+		var tx hexutil.Bytes
+		if err := unmarshalParam(params, 0, &tx); err != nil {
+			return nil, err
+		}
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		n.txs = append(n.txs, tx)
+		return common.BytesToHash(crypto.Keccak256(tx)).Hex(), nil
+	default:
+		return nil, fmt.Errorf("the method %s does not exist/is not available", method)
+	}
+}
+
+func (n *Node) getProof(params []json.RawMessage) (interface{}, error) {
+	var (
+		address common.Address
+		keys    []common.Hash
+	)
+	if err := unmarshalParam(params, 0, &address); err != nil {
+		return nil, err
+	}
+	if err := unmarshalParam(params, 1, &keys); err != nil {
+		return nil, err
+	}
+	number, err := n.blockNumberParam(params, 2)
+	if err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if number >= uint64(len(n.blocks)) {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+	if n.pruned(number) {
+		return nil, missingTrieNode(number)
+	}
+	return getProof(n.blocks[number].storage, address, keys)
+}
+
+func (n *Node) call(params []json.RawMessage) (interface{}, error) {
+	var arg struct {
+		To    *common.Address `json:"to"`
+		Data  hexutil.Bytes   `json:"data"`
+		Input hexutil.Bytes   `json:"input"`
+	}
+	if err := unmarshalParam(params, 0, &arg); err != nil {
+		return nil, err
+	}
+	if arg.To == nil {
+		return nil, fmt.Errorf("contract creation is not supported")
+	}
+	number, err := n.blockNumberParam(params, 1)
+	if err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	handler, ok := n.calls[*arg.To]
+	pruned := n.pruned(number)
+	n.mu.Unlock()
+	if pruned {
+		return nil, missingTrieNode(number)
+	}
+	if !ok {
+		return hexutil.Bytes{}, nil
+	}
+	input := arg.Data
+	if len(input) == 0 {
+		input = arg.Input
+	}
+	out, err := handler(input)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(out), nil
+}
+
+func (n *Node) getLogs(params []json.RawMessage) (interface{}, error) {
+	var q struct {
+		FromBlock string           `json:"fromBlock"`
+		ToBlock   string           `json:"toBlock"`
+		Addresses []common.Address `json:"address"`
+		Topics    [][]common.Hash  `json:"topics"`
+	}
+	if err := unmarshalParam(params, 0, &q); err != nil {
+		return nil, err
+	}
+	from, err := n.parseBlockNumber(q.FromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := n.parseBlockNumber(q.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.logRequests++
+	if n.maxLogRange > 0 && to >= from && to-from+1 > n.maxLogRange {
+		return nil, fmt.Errorf("query must be smaller than size %d", n.maxLogRange)
+	}
+	logs := []*ethtypes.Log{}
+	for _, l := range n.logs {
+		if l.BlockNumber < from || l.BlockNumber > to || !matchLog(l, q.Addresses, q.Topics) {
+			continue
+		}
+		logs = append(logs, l)
+	}
+	return logs, nil
+}
+
+func matchLog(l *ethtypes.Log, addresses []common.Address, topics [][]common.Hash) bool {
+	if len(addresses) > 0 {
+		found := false
+		for _, addr := range addresses {
+			if l.Address == addr {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(topics) > len(l.Topics) {
+		return false
+	}
+	for i, sub := range topics {
+		if len(sub) == 0 {
+			continue
+		}
+		found := false
+		for _, t := range sub {
+			if l.Topics[i] == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *Node) blockNumberParam(params []json.RawMessage, i int) (uint64, error) {
+	if i >= len(params) {
+		return n.BlockNumber(), nil
+	}
+	var arg interface{}
+	if err := json.Unmarshal(params[i], &arg); err != nil {
+		return 0, err
+	}
+	switch arg := arg.(type) {
+	case float64:
+		return uint64(arg), nil
+	case string:
+		return n.parseBlockNumber(arg)
+	default:
+		return 0, fmt.Errorf("invalid block number: %v", arg)
+	}
+}
+
+// parseBlockNumber parses a block number in either hex or decimal, or a block tag
+func (n *Node) parseBlockNumber(s string) (uint64, error) {
+	switch s {
+	case "", "latest", "pending":
+		return n.BlockNumber(), nil
+	case "earliest":
+		return 0, nil
+	}
+	if strings.HasPrefix(s, "0x") {
+		return hexutil.DecodeUint64(s)
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// missingTrieNode returns the error which a non-archive node returns for a query of pruned state
+func missingTrieNode(number uint64) error {
+	return fmt.Errorf("missing trie node of block %d", number)
+}
+
+func unmarshalParam(params []json.RawMessage, i int, v interface{}) error {
+	if i >= len(params) {
+		return fmt.Errorf("missing param %d", i)
+	}
+	return json.Unmarshal(params[i], v)
+}
+
+// toRPCHeader is the inverse of the conversion from a full header to a v3 header done by the relayer
+func toRPCHeader(h *v3.Header) *rpcv2.BlockHeader {
+	sig := h.LastCommitSignature()
+	return &rpcv2.BlockHeader{
+		ParentHash:           h.ParentHash(),
+		Miner:                h.Coinbase().Hex(),
+		StateRoot:            h.Root(),
+		TransactionsRoot:     h.TxHash(),
+		ReceiptsRoot:         h.ReceiptHash(),
+		OutgoingReceiptsRoot: h.OutgoingReceiptHash(),
+		IncomingReceiptsRoot: h.IncomingReceiptHash(),
+		LogsBloom:            h.Bloom(),
+		Number:               new(big.Int).Set(h.Number()),
+		GasLimit:             h.GasLimit(),
+		GasUsed:              h.GasUsed(),
+		Timestamp:            new(big.Int).Set(h.Time()),
+		ExtraData:            h.Extra(),
+		MixHash:              h.MixDigest(),
+		ViewID:               new(big.Int).Set(h.ViewID()),
+		Epoch:                new(big.Int).Set(h.Epoch()),
+		ShardID:              h.ShardID(),
+		LastCommitSignature:  sig[:],
+		LastCommitBitmap:     h.LastCommitBitmap(),
+		Vrf:                  h.Vrf(),
+		Vdf:                  h.Vdf(),
+		ShardState:           h.ShardState(),
+		CrossLink:            h.CrossLinks(),
+		Slashes:              h.Slashes(),
+	}
+}
@@ -0,0 +1,138 @@
+package fakenode
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+var emptyCodeHash = crypto.Keccak256(nil)
+
+// account is the RLP layout of an account in the state trie
+type account struct {
+	Nonce    uint64
+	Balance  *big.Int
+	Root     common.Hash
+	CodeHash []byte
+}
+
+type storageResult struct {
+	Key   string       `json:"key"`
+	Value *hexutil.Big `json:"value"`
+	Proof []string     `json:"proof"`
+}
+
+type accountResult struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []string        `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []storageResult `json:"storageProof"`
+}
+
+// proofList collects trie nodes of a proof from the root to the leaf
+type proofList []string
+
+func (l *proofList) Put(key []byte, value []byte) error {
+	*l = append(*l, hexutil.Encode(value))
+	return nil
+}
+
+func (l *proofList) Delete(key []byte) error {
+	panic("not supported")
+}
+
+func newTrie() *trie.Trie {
+	t, err := trie.New(common.Hash{}, trie.NewDatabase(memorydb.New()))
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func storageTrie(slots map[common.Hash]common.Hash) (*trie.Trie, error) {
+	t := newTrie()
+	for k, v := range slots {
+		value, err := rlp.EncodeToBytes(bytes.TrimLeft(v[:], "\x00"))
+		if err != nil {
+			return nil, err
+		}
+		t.Update(crypto.Keccak256(k[:]), value)
+	}
+	return t, nil
+}
+
+func stateTrie(storage map[common.Address]map[common.Hash]common.Hash) (*trie.Trie, error) {
+	t := newTrie()
+	for addr, slots := range storage {
+		st, err := storageTrie(slots)
+		if err != nil {
+			return nil, err
+		}
+		bz, err := rlp.EncodeToBytes(account{
+			Nonce:    0,
+			Balance:  big.NewInt(0),
+			Root:     st.Hash(),
+			CodeHash: emptyCodeHash,
+		})
+		if err != nil {
+			return nil, err
+		}
+		t.Update(crypto.Keccak256(addr[:]), bz)
+	}
+	return t, nil
+}
+
+func stateRoot(storage map[common.Address]map[common.Hash]common.Hash) (common.Hash, error) {
+	t, err := stateTrie(storage)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return t.Hash(), nil
+}
+
+// getProof returns the account proof and the storage proofs in the format of eth_getProof
+func getProof(storage map[common.Address]map[common.Hash]common.Hash, address common.Address, keys []common.Hash) (*accountResult, error) {
+	state, err := stateTrie(storage)
+	if err != nil {
+		return nil, err
+	}
+	var accountProof proofList
+	if err := state.Prove(crypto.Keccak256(address[:]), 0, &accountProof); err != nil {
+		return nil, err
+	}
+	st, err := storageTrie(storage[address])
+	if err != nil {
+		return nil, err
+	}
+	storageProof := make([]storageResult, len(keys))
+	for i, k := range keys {
+		var proof proofList
+		if err := st.Prove(crypto.Keccak256(k[:]), 0, &proof); err != nil {
+			return nil, err
+		}
+		v := storage[address][k]
+		storageProof[i] = storageResult{
+			Key:   k.Hex(),
+			Value: (*hexutil.Big)(new(big.Int).SetBytes(v[:])),
+			Proof: proof,
+		}
+	}
+	return &accountResult{
+		Address:      address,
+		AccountProof: accountProof,
+		Balance:      (*hexutil.Big)(big.NewInt(0)),
+		CodeHash:     common.BytesToHash(emptyCodeHash),
+		Nonce:        0,
+		StorageHash:  st.Hash(),
+		StorageProof: storageProof,
+	}, nil
+}
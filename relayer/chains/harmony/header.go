@@ -0,0 +1,204 @@
+package harmony
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	blockif "github.com/harmony-one/harmony/block/interface"
+	v0 "github.com/harmony-one/harmony/block/v0"
+	v1 "github.com/harmony-one/harmony/block/v1"
+	v2 "github.com/harmony-one/harmony/block/v2"
+	v3 "github.com/harmony-one/harmony/block/v3"
+	rpcv2 "github.com/harmony-one/harmony/rpc/v2"
+)
+
+// HeaderCodec builds and decodes the headers of a version
+type HeaderCodec interface {
+	// Convert builds a header from the one returned by the RPC
+	Convert(bh *rpcv2.BlockHeader) (blockif.Header, error)
+	// Decode decodes a RLP encoded header
+	Decode(bz []byte) (blockif.Header, error)
+}
+
+var (
+	headerCodecsMu sync.RWMutex
+	headerCodecs   = map[string]HeaderCodec{
+		"v0": NewHeaderCodec(func() blockif.Header { return v0.NewHeader() }),
+		"v1": NewHeaderCodec(func() blockif.Header { return v1.NewHeader() }),
+		"v2": NewHeaderCodec(func() blockif.Header { return v2.NewHeader() }),
+		"v3": NewHeaderCodec(func() blockif.Header { return v3.NewHeader() }),
+	}
+)
+
+// defaultHeaderVersions are used if no header versions are configured
+var defaultHeaderVersions = []*HeaderVersion{{Epoch: 0, Version: "v3"}}
+
+// RegisterHeaderCodec registers the codec of a header version, replacing the existing one of the same version
+func RegisterHeaderCodec(version string, codec HeaderCodec) {
+	headerCodecsMu.Lock()
+	defer headerCodecsMu.Unlock()
+	headerCodecs[version] = codec
+}
+
+func lookupHeaderCodec(version string) (HeaderCodec, bool) {
+	headerCodecsMu.RLock()
+	defer headerCodecsMu.RUnlock()
+	codec, ok := headerCodecs[version]
+	return codec, ok
+}
+
+// NewHeaderCodec returns a codec of the headers created by newHeader
+func NewHeaderCodec(newHeader func() blockif.Header) HeaderCodec {
+	return headerCodec{newHeader: newHeader}
+}
+
+type headerCodec struct {
+	newHeader func() blockif.Header
+}
+
+func (c headerCodec) Convert(bh *rpcv2.BlockHeader) (blockif.Header, error) {
+	h := c.newHeader()
+	h.SetParentHash(bh.ParentHash)
+	h.SetCoinbase(common.HexToAddress(bh.Miner))
+	h.SetRoot(bh.StateRoot)
+	h.SetTxHash(bh.TransactionsRoot)
+	h.SetReceiptHash(bh.ReceiptsRoot)
+	h.SetBloom(bh.LogsBloom)
+	h.SetNumber(bh.Number)
+	h.SetGasLimit(bh.GasLimit)
+	h.SetGasUsed(bh.GasUsed)
+	h.SetTime(bh.Timestamp)
+	h.SetExtra(bh.ExtraData)
+	h.SetMixDigest(bh.MixHash)
+	h.SetViewID(bh.ViewID)
+	h.SetEpoch(bh.Epoch)
+	h.SetShardID(bh.ShardID)
+	var commitSig [96]byte
+	copy(commitSig[:], bh.LastCommitSignature)
+	h.SetLastCommitSignature(commitSig)
+	h.SetLastCommitBitmap(bh.LastCommitBitmap)
+	// older versions don't have the following fields, and complain when they are set
+	if bh.OutgoingReceiptsRoot != (common.Hash{}) {
+		h.SetOutgoingReceiptHash(bh.OutgoingReceiptsRoot)
+	}
+	if bh.IncomingReceiptsRoot != (common.Hash{}) {
+		h.SetIncomingReceiptHash(bh.IncomingReceiptsRoot)
+	}
+	if len(bh.Vrf) > 0 {
+		h.SetVrf(bh.Vrf)
+	}
+	if len(bh.Vdf) > 0 {
+		h.SetVdf(bh.Vdf)
+	}
+	if len(bh.ShardState) > 0 {
+		h.SetShardState(bh.ShardState)
+	}
+	if len(bh.CrossLink) > 0 {
+		h.SetCrossLinks(bh.CrossLink)
+	}
+	if len(bh.Slashes) > 0 {
+		h.SetSlashes(bh.Slashes)
+	}
+	return h, nil
+}
+
+func (c headerCodec) Decode(bz []byte) (blockif.Header, error) {
+	h := c.newHeader()
+	if err := rlp.DecodeBytes(bz, h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// headerVersions selects the codec of a header by its epoch
+type headerVersions struct {
+	// sorted by epoch in ascending order
+	versions []*HeaderVersion
+}
+
+// newHeaderVersions returns the header versions of the config, or the default ones if none are configured
+func newHeaderVersions(versions []*HeaderVersion) (*headerVersions, error) {
+	if len(versions) == 0 {
+		versions = defaultHeaderVersions
+	}
+	sorted := append([]*HeaderVersion{}, versions...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Epoch < sorted[j].Epoch })
+	for i, v := range sorted {
+		if _, ok := lookupHeaderCodec(v.Version); !ok {
+			return nil, fmt.Errorf("unsupported header version %q from epoch %d", v.Version, v.Epoch)
+		}
+		if i > 0 && sorted[i-1].Epoch == v.Epoch {
+			return nil, fmt.Errorf("multiple header versions from epoch %d", v.Epoch)
+		}
+	}
+	return &headerVersions{versions: sorted}, nil
+}
+
+// forEpoch returns the header version of the given epoch and its codec
+func (hv *headerVersions) forEpoch(epoch uint64) (string, HeaderCodec, error) {
+	for i := len(hv.versions) - 1; i >= 0; i-- {
+		v := hv.versions[i]
+		if v.Epoch > epoch {
+			continue
+		}
+		codec, ok := lookupHeaderCodec(v.Version)
+		if !ok {
+			return "", nil, fmt.Errorf("unsupported header version %q at epoch %d", v.Version, epoch)
+		}
+		return v.Version, codec, nil
+	}
+	return "", nil, fmt.Errorf("no header version is configured for epoch %d", epoch)
+}
+
+// convert builds a header of the version of its epoch from the one returned by the RPC
+func (hv *headerVersions) convert(bh *rpcv2.BlockHeader) (blockif.Header, error) {
+	if bh.Epoch == nil {
+		return nil, fmt.Errorf("header %v has no epoch", bh.Number)
+	}
+	_, codec, err := hv.forEpoch(bh.Epoch.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	return codec.Convert(bh)
+}
+
+// encode returns the RLP encoding of a header returned by the RPC in the version of its epoch
+func (hv *headerVersions) encode(bh *rpcv2.BlockHeader) ([]byte, error) {
+	h, err := hv.convert(bh)
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(h)
+}
+
+// decode decodes a RLP encoded header.
+// Since the encoding doesn't contain the version, the configured versions are tried from the newest one,
+// and a decoded header is accepted only if its epoch is of the version.
+func (hv *headerVersions) decode(bz []byte) (blockif.Header, error) {
+	var lastErr error
+	for i := len(hv.versions) - 1; i >= 0; i-- {
+		v := hv.versions[i]
+		codec, ok := lookupHeaderCodec(v.Version)
+		if !ok {
+			return nil, fmt.Errorf("unsupported header version %q", v.Version)
+		}
+		h, err := codec.Decode(bz)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		version, _, err := hv.forEpoch(h.Epoch().Uint64())
+		if err != nil {
+			return nil, err
+		}
+		if version != v.Version {
+			lastErr = fmt.Errorf("header of epoch %v is decoded as %s, but it must be %s", h.Epoch(), v.Version, version)
+			continue
+		}
+		return h, nil
+	}
+	return nil, fmt.Errorf("failed to decode the header with any of the configured header versions: %w", lastErr)
+}
@@ -0,0 +1,81 @@
+package harmony
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	rpcv2 "github.com/harmony-one/harmony/rpc/v2"
+)
+
+func testBlockHeader(number, epoch int64) *rpcv2.BlockHeader {
+	return &rpcv2.BlockHeader{
+		ParentHash: common.HexToHash("0x01"),
+		Miner:      common.HexToAddress("0x02").Hex(),
+		Number:     big.NewInt(number),
+		Timestamp:  big.NewInt(1000),
+		ViewID:     big.NewInt(number),
+		Epoch:      big.NewInt(epoch),
+		ShardID:    1,
+	}
+}
+
+func TestHeaderVersions(t *testing.T) {
+	hv, err := newHeaderVersions([]*HeaderVersion{{Epoch: 5, Version: "v3"}, {Epoch: 1, Version: "v2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		epoch   uint64
+		version string
+	}{
+		{1, "v2"},
+		{4, "v2"},
+		{5, "v3"},
+		{100, "v3"},
+	}
+	for _, c := range cases {
+		version, _, err := hv.forEpoch(c.epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version != c.version {
+			t.Errorf("version of epoch %d = %s, want %s", c.epoch, version, c.version)
+		}
+	}
+	if _, _, err := hv.forEpoch(0); err == nil || !strings.Contains(err.Error(), "no header version") {
+		t.Fatalf("unexpected error for an epoch before the first version: %v", err)
+	}
+
+	// each header is decoded in the version of its epoch
+	for _, c := range cases {
+		bz, err := hv.encode(testBlockHeader(10, int64(c.epoch)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		h, err := hv.decode(bz)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.Epoch().Uint64() != c.epoch || h.Number().Uint64() != 10 {
+			t.Fatalf("unexpected header: epoch %v, number %v", h.Epoch(), h.Number())
+		}
+	}
+}
+
+func TestHeaderVersionsInvalid(t *testing.T) {
+	if _, err := newHeaderVersions([]*HeaderVersion{{Epoch: 0, Version: "v9"}}); err == nil || !strings.Contains(err.Error(), `unsupported header version "v9"`) {
+		t.Fatalf("unexpected error for an unsupported version: %v", err)
+	}
+	if _, err := newHeaderVersions([]*HeaderVersion{{Epoch: 3, Version: "v2"}, {Epoch: 3, Version: "v3"}}); err == nil {
+		t.Fatal("duplicate epochs must be rejected")
+	}
+	hv, err := newHeaderVersions(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version, _, err := hv.forEpoch(0); err != nil || version != "v3" {
+		t.Fatalf("unexpected default version: %s, %v", version, err)
+	}
+}
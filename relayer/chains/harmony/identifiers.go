@@ -0,0 +1,169 @@
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
+	"github.com/cosmos/ibc-go/modules/core/exported"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// generatedIdentifier is an identifier generated by IBCHost, and the transaction which generated it
+type generatedIdentifier struct {
+	ID     string
+	TxHash common.Hash
+}
+
+// QueryClients returns all clients created on IBCHost at the given height, or the latest height if it's 0
+func (c *Chain) QueryClients(height int64) (*clienttypes.QueryClientStatesResponse, error) {
+	ctx := context.Background()
+	height, err := c.heightOrLatest(height)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := c.findGeneratedIdentifiers(ctx, abiGeneratedClientIdentifier, height)
+	if err != nil {
+		return nil, err
+	}
+	res := &clienttypes.QueryClientStatesResponse{}
+	for _, id := range ids {
+		s, found, err := c.ibcHost.GetClientState(c.CallOpts(ctx, height), id.ID)
+		if err != nil {
+			return nil, err
+		} else if !found {
+			return nil, fmt.Errorf("client not found: %v", id.ID)
+		}
+		var clientState exported.ClientState
+		if err := c.Codec().UnmarshalInterface(s, &clientState); err != nil {
+			return nil, err
+		}
+		res.ClientStates = append(res.ClientStates, clienttypes.NewIdentifiedClientState(id.ID, clientState))
+	}
+	return res, nil
+}
+
+// QueryConnections returns all connections created on IBCHost at the given height, or the latest height if it's 0
+func (c *Chain) QueryConnections(height int64) (*conntypes.QueryConnectionsResponse, error) {
+	ctx := context.Background()
+	height, err := c.heightOrLatest(height)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := c.findGeneratedIdentifiers(ctx, abiGeneratedConnectionIdentifier, height)
+	if err != nil {
+		return nil, err
+	}
+	res := &conntypes.QueryConnectionsResponse{Height: clienttypes.NewHeight(0, uint64(height))}
+	for _, id := range ids {
+		conn, found, err := c.ibcHost.GetConnection(c.CallOpts(ctx, height), id.ID)
+		if err != nil {
+			return nil, err
+		} else if !found {
+			return nil, fmt.Errorf("connection not found: %v", id.ID)
+		}
+		identified := conntypes.NewIdentifiedConnection(id.ID, connectionEndToPB(conn))
+		res.Connections = append(res.Connections, &identified)
+	}
+	return res, nil
+}
+
+// QueryChannels returns all channels created on IBCHost at the given height, or the latest height if it's 0.
+// Since IBCHost doesn't emit the ports of channels, they are found in the transactions which opened the channels.
+func (c *Chain) QueryChannels(height int64) (*chantypes.QueryChannelsResponse, error) {
+	ctx := context.Background()
+	height, err := c.heightOrLatest(height)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := c.findGeneratedIdentifiers(ctx, abiGeneratedChannelIdentifier, height)
+	if err != nil {
+		return nil, err
+	}
+	res := &chantypes.QueryChannelsResponse{Height: clienttypes.NewHeight(0, uint64(height))}
+	for _, id := range ids {
+		portID, err := c.channelPortID(ctx, id.TxHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the port of channel %v: %w", id.ID, err)
+		}
+		chann, found, err := c.ibcHost.GetChannel(c.CallOpts(ctx, height), portID, id.ID)
+		if err != nil {
+			return nil, err
+		} else if !found {
+			return nil, fmt.Errorf("channel not found: %v:%v", portID, id.ID)
+		}
+		identified := chantypes.NewIdentifiedChannel(portID, id.ID, channelToPB(chann))
+		res.Channels = append(res.Channels, &identified)
+	}
+	return res, nil
+}
+
+// heightOrLatest returns the given height, or the latest height if it's 0
+func (c *Chain) heightOrLatest(height int64) (int64, error) {
+	if height > 0 {
+		return height, nil
+	}
+	return c.GetLatestHeight()
+}
+
+// findGeneratedIdentifiers returns the identifiers generated by IBCHost up to the given height in the order of generation
+func (c *Chain) findGeneratedIdentifiers(ctx context.Context, event abi.Event, height int64) ([]generatedIdentifier, error) {
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{
+			c.config.IBCHostAddress(),
+		},
+		Topics: [][]common.Hash{{
+			event.ID(),
+		}},
+		ToBlock: big.NewInt(height),
+	}
+	logs, err := c.findLogs(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]generatedIdentifier, len(logs))
+	for i, l := range logs {
+		id, err := unpackIdentifier(event, l.Data)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = generatedIdentifier{ID: id, TxHash: l.TxHash}
+	}
+	return ids, nil
+}
+
+// channelPortID returns the port of the channel opened by the given transaction to IBCHandler
+func (c *Chain) channelPortID(ctx context.Context, txHash common.Hash) (string, error) {
+	input, err := c.client.TransactionInput(ctx, txHash)
+	if err != nil {
+		return "", err
+	}
+	if len(input) < 4 {
+		return "", fmt.Errorf("transaction %s doesn't call IBCHandler", txHash.Hex())
+	}
+	method, err := parsedHandlerABI.MethodById(input[:4])
+	if err != nil {
+		return "", err
+	}
+	if method.Name != methodChannelOpenInit && method.Name != methodChannelOpenTry {
+		return "", fmt.Errorf("transaction %s doesn't open a channel: %s", txHash.Hex(), method.Name)
+	}
+	values, err := method.Inputs.UnpackValues(input[4:])
+	if err != nil {
+		return "", err
+	}
+	if len(values) != 1 {
+		return "", fmt.Errorf("unexpected number of arguments of %s: %d", method.Name, len(values))
+	}
+	portID := reflect.ValueOf(values[0]).FieldByName("PortId")
+	if !portID.IsValid() || portID.Kind() != reflect.String {
+		return "", fmt.Errorf("unexpected argument of %s: %v", method.Name, values[0])
+	}
+	return portID.String(), nil
+}
@@ -0,0 +1,130 @@
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	tmclient "github.com/cosmos/ibc-go/modules/light-clients/07-tendermint/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/ibchandler"
+)
+
+func TestQueryIdentifiers(t *testing.T) {
+	env := newTestEnv(t, 0)
+	clientState := &tmclient.ClientState{ChainId: "ibc0", LatestHeight: clienttypes.NewHeight(0, 10)}
+	env.beacon.HandleCall(testIBCHostAddress, ibcHostCallHandler(t, env.chain.Codec(), clientState, &tmclient.ConsensusState{}, common.Hash{}))
+
+	clientLog := identifierLog(t, testIBCHostAddress, abiGeneratedClientIdentifier, "07-tendermint-0")
+	env.beacon.AddLog(clientLog.Address, clientLog.Topics, clientLog.Data)
+	connLog := identifierLog(t, testIBCHostAddress, abiGeneratedConnectionIdentifier, "connection-0")
+	env.beacon.AddLog(connLog.Address, connLog.Topics, connLog.Data)
+	input, err := parsedHandlerABI.Pack(methodChannelOpenInit, ibchandler.IBCMsgsMsgChannelOpenInit{
+		PortId: "transfer",
+		Channel: ibchandler.ChannelData{
+			Counterparty:   ibchandler.ChannelCounterpartyData{PortId: "transfer"},
+			ConnectionHops: []string{"connection-0"},
+			Version:        "ics20-1",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chanLog := identifierLog(t, testIBCHostAddress, abiGeneratedChannelIdentifier, "channel-0")
+	env.beacon.AddTransactionLog(input, chanLog.Address, chanLog.Topics, chanLog.Data)
+	height := env.beacon.MineBlock().Number().Int64()
+
+	// identifiers generated after the height are excluded
+	laterLog := identifierLog(t, testIBCHostAddress, abiGeneratedClientIdentifier, "07-tendermint-1")
+	env.beacon.AddLog(laterLog.Address, laterLog.Topics, laterLog.Data)
+	env.beacon.MineBlock()
+
+	clients, err := env.chain.QueryClients(height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clients.ClientStates) != 1 || clients.ClientStates[0].ClientId != "07-tendermint-0" {
+		t.Fatalf("unexpected clients: %v", clients.ClientStates)
+	}
+	clients, err = env.chain.QueryClients(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clients.ClientStates) != 2 || clients.ClientStates[1].ClientId != "07-tendermint-1" {
+		t.Fatalf("unexpected clients at the latest height: %v", clients.ClientStates)
+	}
+
+	conns, err := env.chain.QueryConnections(height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conns.Connections) != 1 || conns.Connections[0].Id != "connection-0" || conns.Connections[0].ClientId != "07-tendermint-0" {
+		t.Fatalf("unexpected connections: %v", conns.Connections)
+	}
+
+	chans, err := env.chain.QueryChannels(height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chans.Channels) != 1 || chans.Channels[0].PortId != "transfer" || chans.Channels[0].ChannelId != "channel-0" {
+		t.Fatalf("unexpected channels: %v", chans.Channels)
+	}
+}
+
+func TestFindGeneratedIdentifiersByPages(t *testing.T) {
+	env := newTestEnv(t, 0)
+	defer func(size uint64) { logsPageSize = size }(logsPageSize)
+	logsPageSize = 4
+	env.beacon.SetMaxLogRange(logsPageSize)
+
+	// the identifiers are generated in blocks 2, 7 and 11, which are in different pages
+	for i, height := range []uint64{2, 7, 11} {
+		mineBlocks(env.beacon, height-1)
+		l := identifierLog(t, testIBCHostAddress, abiGeneratedClientIdentifier, fmt.Sprintf("07-tendermint-%d", i))
+		env.beacon.AddLog(l.Address, l.Topics, l.Data)
+		env.beacon.MineBlock()
+	}
+	mineBlocks(env.beacon, 13)
+
+	ids, err := env.chain.findGeneratedIdentifiers(context.Background(), abiGeneratedClientIdentifier, 13)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 || ids[0].ID != "07-tendermint-0" || ids[1].ID != "07-tendermint-1" || ids[2].ID != "07-tendermint-2" {
+		t.Fatalf("unexpected identifiers: %v", ids)
+	}
+
+	// the blocks searched before aren't requested again, and the identifiers after the height are excluded
+	requests := env.beacon.LogRequests()
+	l := identifierLog(t, testIBCHostAddress, abiGeneratedClientIdentifier, "07-tendermint-3")
+	env.beacon.AddLog(l.Address, l.Topics, l.Data)
+	env.beacon.MineBlock()
+	ids, err = env.chain.findGeneratedIdentifiers(context.Background(), abiGeneratedClientIdentifier, 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 4 || ids[3].ID != "07-tendermint-3" {
+		t.Fatalf("unexpected identifiers after a new block: %v", ids)
+	}
+	if n := env.beacon.LogRequests() - requests; n != 1 {
+		t.Fatalf("unexpected number of getLogs requests for a new block: %d", n)
+	}
+	ids, err = env.chain.findGeneratedIdentifiers(context.Background(), abiGeneratedClientIdentifier, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 || env.beacon.LogRequests()-requests != 1 {
+		t.Fatalf("unexpected identifiers up to a searched height: %v", ids)
+	}
+
+	// blocks before the deployment of IBCHost are skipped
+	env.chain.config.IbcHostDeploymentHeight = 5
+	ids, err = env.chain.findGeneratedIdentifiers(context.Background(), abiGeneratedClientIdentifier, 13)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0].ID != "07-tendermint-1" || ids[1].ID != "07-tendermint-2" {
+		t.Fatalf("unexpected identifiers after the deployment height: %v", ids)
+	}
+}
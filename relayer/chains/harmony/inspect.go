@@ -0,0 +1,185 @@
+package harmony
+
+import (
+	"context"
+	"encoding/hex"
+	"math/bits"
+
+	hmylctypes "github.com/datachainlab/ibc-harmony-client/modules/light-clients/harmony/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/block"
+	blockif "github.com/harmony-one/harmony/block/interface"
+	hmytypes "github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/shard"
+)
+
+// HeaderInspection is the decoded content of a header built by the prover
+type HeaderInspection struct {
+	BeaconHeader   *DecodedHeader     `json:"beacon_header"`
+	ShardHeader    *DecodedHeader     `json:"shard_header,omitempty"`
+	CrossLinks     []DecodedCrossLink `json:"cross_links"`
+	CrossLinkIndex uint32             `json:"cross_link_index"`
+	// Committee is the beacon committee which signs the beacon header, or empty if no shard state is available
+	Committee []CommitteeMember `json:"committee"`
+	// Signers is the number of signers in the commit bitmap of the beacon header
+	Signers      int              `json:"signers"`
+	EpochHeaders []*DecodedHeader `json:"epoch_headers,omitempty"`
+	// Header is the header submitted to the light client
+	Header *hmylctypes.Header `json:"header"`
+}
+
+// DecodedHeader is a decoded header
+type DecodedHeader struct {
+	Number              uint64        `json:"number"`
+	Hash                common.Hash   `json:"hash"`
+	ParentHash          common.Hash   `json:"parent_hash"`
+	ShardID             uint32        `json:"shard_id"`
+	Epoch               uint64        `json:"epoch"`
+	ViewID              uint64        `json:"view_id"`
+	Time                uint64        `json:"time"`
+	StateRoot           common.Hash   `json:"state_root"`
+	LastCommitSignature hexutil.Bytes `json:"last_commit_signature"`
+	LastCommitBitmap    hexutil.Bytes `json:"last_commit_bitmap"`
+	HasShardState       bool          `json:"has_shard_state"`
+}
+
+// DecodedCrossLink is a decoded cross link in a beacon header
+type DecodedCrossLink struct {
+	ShardID     uint32      `json:"shard_id"`
+	BlockNumber uint64      `json:"block_number"`
+	Hash        common.Hash `json:"hash"`
+	ViewID      uint64      `json:"view_id"`
+	Epoch       uint64      `json:"epoch"`
+	Signers     int         `json:"signers"`
+}
+
+// CommitteeMember is a slot of a committee
+type CommitteeMember struct {
+	Address      common.Address `json:"address"`
+	BLSPublicKey string         `json:"bls_public_key"`
+}
+
+// QueryHeader returns the header which the prover builds at the given beacon height
+func (pr *Prover) QueryHeader(height uint64) (*hmylctypes.Header, error) {
+	ctx := context.Background()
+	if pr.config.TracksBeacon(pr.chain.config.ShardId) {
+		return pr.queryHeaderForBeacon(ctx, height)
+	}
+	return pr.queryHeaderForShard(ctx, height)
+}
+
+// QueryEpochHeader returns the header of the last beacon block of the given epoch
+func (pr *Prover) QueryEpochHeader(epoch uint64) (*hmylctypes.Header, error) {
+	return pr.queryEpochLastHeader(context.Background(), epoch, pr.config.TracksBeacon(pr.chain.config.ShardId))
+}
+
+// InspectHeader decodes the given header, and finds the committee which signs its beacon header
+func (pr *Prover) InspectHeader(header *hmylctypes.Header) (*HeaderInspection, error) {
+	bh, err := pr.chain.headers.decode(header.BeaconHeader.Header)
+	if err != nil {
+		return nil, err
+	}
+	out := &HeaderInspection{
+		BeaconHeader:   decodedHeader(bh),
+		CrossLinkIndex: header.CrossLinkIndex,
+		Signers:        countSigners(header.BeaconHeader.CommitBitmap),
+		Header:         header,
+	}
+	if len(header.ShardHeader) > 0 {
+		sh, err := pr.chain.headers.decode(header.ShardHeader)
+		if err != nil {
+			return nil, err
+		}
+		out.ShardHeader = decodedHeader(sh)
+	}
+	if len(bh.CrossLinks()) > 0 {
+		var crossLinks hmytypes.CrossLinks
+		if err := rlp.DecodeBytes(bh.CrossLinks(), &crossLinks); err != nil {
+			return nil, err
+		}
+		for _, cl := range crossLinks {
+			out.CrossLinks = append(out.CrossLinks, DecodedCrossLink{
+				ShardID:     cl.ShardIDF,
+				BlockNumber: cl.BlockNumberF.Uint64(),
+				Hash:        cl.HashF,
+				ViewID:      cl.ViewIDF.Uint64(),
+				Epoch:       cl.EpochF.Uint64(),
+				Signers:     countSigners(cl.BitmapF),
+			})
+		}
+	}
+	for _, eh := range header.EpochHeaders {
+		h, err := pr.chain.headers.decode(eh.Header)
+		if err != nil {
+			return nil, err
+		}
+		out.EpochHeaders = append(out.EpochHeaders, decodedHeader(h))
+	}
+	committee, err := pr.queryBeaconCommittee(context.Background(), bh.Epoch().Uint64())
+	if err != nil {
+		return nil, err
+	}
+	if committee != nil {
+		for _, slot := range committee.Slots {
+			out.Committee = append(out.Committee, CommitteeMember{
+				Address:      slot.EcdsaAddress,
+				BLSPublicKey: hex.EncodeToString(slot.BLSPublicKey[:]),
+			})
+		}
+	}
+	return out, nil
+}
+
+// queryBeaconCommittee returns the beacon committee of the given epoch,
+// which is in the shard state of the last beacon header of the previous epoch.
+// It returns nil if the header has no shard state.
+func (pr *Prover) queryBeaconCommittee(ctx context.Context, epoch uint64) (*shard.Committee, error) {
+	var height uint64
+	if epoch > 0 {
+		var err error
+		if height, err = pr.beaconClient.EpochLastBlockNumber(ctx, epoch-1); err != nil {
+			return nil, err
+		}
+	}
+	header, err := pr.beaconClient.FullHeader(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	if len(header.ShardState) == 0 {
+		return nil, nil
+	}
+	var shardState shard.State
+	if err := rlp.DecodeBytes(header.ShardState, &shardState); err != nil {
+		return nil, err
+	}
+	return shardState.FindCommitteeByID(0)
+}
+
+func decodedHeader(h blockif.Header) *DecodedHeader {
+	sig := h.LastCommitSignature()
+	b := block.Header{Header: h}
+	return &DecodedHeader{
+		Number:              h.Number().Uint64(),
+		Hash:                b.Hash(),
+		ParentHash:          h.ParentHash(),
+		ShardID:             h.ShardID(),
+		Epoch:               h.Epoch().Uint64(),
+		ViewID:              h.ViewID().Uint64(),
+		Time:                h.Time().Uint64(),
+		StateRoot:           h.Root(),
+		LastCommitSignature: sig[:],
+		LastCommitBitmap:    h.LastCommitBitmap(),
+		HasShardState:       len(h.ShardState()) > 0,
+	}
+}
+
+// countSigners returns the number of signers in a commit bitmap
+func countSigners(bitmap []byte) int {
+	n := 0
+	for _, b := range bitmap {
+		n += bits.OnesCount8(b)
+	}
+	return n
+}
@@ -0,0 +1,92 @@
+package harmony
+
+import (
+	"testing"
+
+	hmylctypes "github.com/datachainlab/ibc-harmony-client/modules/light-clients/harmony/types"
+)
+
+func TestInspectHeader(t *testing.T) {
+	env := newTestEnv(t, 1)
+	mineBlocks(env.shard, 3)
+	mineBlocks(env.beacon, 1)
+	env.beacon.MineBlock(env.shard.CrossLink(2), env.shard.CrossLink(3))
+	mineBlocks(env.beacon, 4)
+
+	header, err := env.prover.QueryHeader(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := env.prover.InspectHeader(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.BeaconHeader.Number != 2 || out.BeaconHeader.ShardID != 0 {
+		t.Fatalf("unexpected beacon header: %+v", out.BeaconHeader)
+	}
+	if out.ShardHeader == nil || out.ShardHeader.Number != 3 || out.ShardHeader.ShardID != 1 {
+		t.Fatalf("unexpected shard header: %+v", out.ShardHeader)
+	}
+	if len(out.CrossLinks) != 2 || out.CrossLinks[out.CrossLinkIndex].BlockNumber != 3 {
+		t.Fatalf("unexpected cross links: %+v (index %d)", out.CrossLinks, out.CrossLinkIndex)
+	}
+	if out.CrossLinks[out.CrossLinkIndex].Hash != out.ShardHeader.Hash {
+		t.Fatal("the cross link must point to the shard header")
+	}
+	// the fake node sets all bits of commit bitmaps
+	if out.Signers != 8 {
+		t.Fatalf("unexpected number of signers: %d", out.Signers)
+	}
+	// the fake node has no shard state
+	if len(out.Committee) != 0 {
+		t.Fatalf("unexpected committee: %v", out.Committee)
+	}
+}
+
+func TestQueryEpochHeaderForShard(t *testing.T) {
+	env := newTestEnv(t, 1)
+	mineBlocks(env.shard, 3)
+	// the last block of epoch 0 includes the cross links
+	mineBlocks(env.beacon, env.beacon.EpochLastBlockNumber(0)-1)
+	env.beacon.MineBlock(env.shard.CrossLink(2), env.shard.CrossLink(3))
+	mineBlocks(env.beacon, env.beacon.EpochLastBlockNumber(0)+1)
+
+	header, err := env.prover.QueryEpochHeader(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(header.ShardHeader) == 0 {
+		t.Fatal("the epoch header must contain the shard header")
+	}
+	sh, err := env.chain.headers.decode(header.ShardHeader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sh.Number().Uint64() != 3 || header.CrossLinkIndex != 1 {
+		t.Fatalf("unexpected shard header: height=%v index=%v", sh.Number(), header.CrossLinkIndex)
+	}
+	proof, err := decodeRLP(header.AccountProof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hmylctypes.VerifyProof(sh.Root(), testIBCHostAddress.Bytes(), proof); err != nil {
+		t.Fatalf("failed to verify the account proof: %v", err)
+	}
+}
+
+func TestCountSigners(t *testing.T) {
+	cases := []struct {
+		bitmap []byte
+		want   int
+	}{
+		{nil, 0},
+		{[]byte{0x00}, 0},
+		{[]byte{0x01, 0x80}, 2},
+		{[]byte{0xff, 0x0f}, 12},
+	}
+	for _, c := range cases {
+		if got := countSigners(c.bitmap); got != c.want {
+			t.Errorf("countSigners(%x) = %d, want %d", c.bitmap, got, c.want)
+		}
+	}
+}
@@ -1,12 +1,16 @@
 package harmony
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 
+	hmylctypes "github.com/datachainlab/ibc-harmony-client/modules/light-clients/harmony/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rlp"
 	eth "github.com/harmony-one/go-sdk/pkg/rpc/eth"
 )
@@ -18,8 +22,8 @@ type ETHProof struct {
 	StorageProofRLP [][]byte
 }
 
-func (cl Client) GetETHProof(address common.Address, storageKeys [][]byte, blockNumber *big.Int) (*ETHProof, error) {
-	bz, err := cl.getProof(address, storageKeys, "0x"+blockNumber.Text(16))
+func (cl *Client) GetETHProof(ctx context.Context, address common.Address, storageKeys [][]byte, blockNumber *big.Int) (*ETHProof, error) {
+	bz, err := cl.getProof(ctx, address, storageKeys, blockNumber.Uint64())
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +53,7 @@ func (cl Client) GetETHProof(address common.Address, storageKeys [][]byte, block
 	return &encodedProof, nil
 }
 
-func (cl Client) getProof(address common.Address, storageKeys [][]byte, blockNumber string) ([]byte, error) {
+func (cl *Client) getProof(ctx context.Context, address common.Address, storageKeys [][]byte, blockNumber uint64) ([]byte, error) {
 	hashes := []common.Hash{}
 	for _, k := range storageKeys {
 		var h common.Hash
@@ -58,16 +62,12 @@ func (cl Client) getProof(address common.Address, storageKeys [][]byte, blockNum
 		}
 		hashes = append(hashes, h)
 	}
-	rep, err := cl.messenger.SendRPC(eth.Method.GetProof, []interface{}{
-		address, hashes, blockNumber,
+	val, err := cl.sendRPCAt(ctx, blockNumber, eth.Method.GetProof, []interface{}{
+		address, hashes, hexutil.EncodeUint64(blockNumber),
 	})
 	if err != nil {
 		return nil, err
 	}
-	val, ok := rep["result"]
-	if !ok {
-		return nil, errors.New("invalid response")
-	}
 	return json.Marshal(val)
 }
 
@@ -120,3 +120,46 @@ func decodeStorageHash(accountRLP []byte) ([]byte, error) {
 	}
 	return account[AccountStorageRootIndex], nil
 }
+
+// verifyAccountProof verifies the account proof against the state root, and returns the RLP encoded account
+func verifyAccountProof(stateRoot common.Hash, address common.Address, accountProofRLP []byte) ([]byte, error) {
+	proof, err := decodeRLP(accountProofRLP)
+	if err != nil {
+		return nil, err
+	}
+	accountRLP, err := hmylctypes.VerifyProof(stateRoot, address.Bytes(), proof)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account proof of %v against state root %v: %w", address.Hex(), stateRoot.Hex(), err)
+	} else if len(accountRLP) == 0 {
+		return nil, fmt.Errorf("account %v not found in state root %v", address.Hex(), stateRoot.Hex())
+	}
+	return accountRLP, nil
+}
+
+// verifyStorageProof verifies the account proof against the state root,
+// and then the storage proof against the storage root of the account.
+// It returns the RLP encoded value of the slot, which is empty if the proof is a valid non-membership proof
+// of a slot that isn't set, e.g. the commitment of an uninitialized connection or a cleared packet.
+func verifyStorageProof(stateRoot common.Hash, address common.Address, slot []byte, proof *ETHProof) ([]byte, error) {
+	accountRLP, err := verifyAccountProof(stateRoot, address, proof.AccountProofRLP)
+	if err != nil {
+		return nil, err
+	}
+	storageHash, err := decodeStorageHash(accountRLP)
+	if err != nil {
+		return nil, err
+	}
+	if len(proof.StorageProofRLP) == 0 {
+		return nil, errors.New("storage proof is empty")
+	}
+	storageProof, err := decodeRLP(proof.StorageProofRLP[0])
+	if err != nil {
+		return nil, err
+	}
+	storageRoot := common.BytesToHash(storageHash)
+	value, err := hmylctypes.VerifyProof(storageRoot, slot, storageProof)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage proof of slot 0x%x against storage root %v: %w", slot, storageRoot.Hex(), err)
+	}
+	return value, nil
+}
@@ -0,0 +1,93 @@
+package harmony
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestVerifyStorageProof(t *testing.T) {
+	env := newTestEnv(t, 0)
+	slot := crypto.Keccak256([]byte("slot"))
+	env.shard.SetStorage(testIBCHostAddress, common.BytesToHash(slot), crypto.Keccak256Hash([]byte("value")))
+	height := env.shard.MineBlock().Number().Uint64()
+	// a block whose state differs from the proof height
+	env.shard.SetStorage(testIBCHostAddress, common.BytesToHash(slot), crypto.Keccak256Hash([]byte("other")))
+	otherHeight := env.shard.MineBlock().Number().Uint64()
+
+	getProof := func(slot []byte) *ETHProof {
+		proof, err := env.chain.client.GetETHProof(context.Background(), testIBCHostAddress, [][]byte{hexKey(slot)}, new(big.Int).SetUint64(height))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return proof
+	}
+	root := env.shard.Header(height).Root()
+
+	if value, err := verifyStorageProof(root, testIBCHostAddress, slot, getProof(slot)); err != nil {
+		t.Fatalf("failed to verify a valid proof: %v", err)
+	} else if len(value) == 0 {
+		t.Fatal("the value of a set slot must not be empty")
+	}
+	// a slot which isn't set is proven by a non-membership proof
+	emptySlot := crypto.Keccak256([]byte("empty"))
+	if value, err := verifyStorageProof(root, testIBCHostAddress, emptySlot, getProof(emptySlot)); err != nil {
+		t.Fatalf("failed to verify a non-membership proof: %v", err)
+	} else if len(value) != 0 {
+		t.Fatalf("unexpected value of a slot which isn't set: %x", value)
+	}
+
+	cases := []struct {
+		name     string
+		root     common.Hash
+		address  common.Address
+		slot     []byte
+		proof    *ETHProof
+		contains string
+	}{
+		{"wrong state root", env.shard.Header(otherHeight).Root(), testIBCHostAddress, slot, getProof(slot), "invalid account proof"},
+		{"wrong account", root, common.HexToAddress("0x01"), slot, getProof(slot), "account"},
+		{"wrong slot", root, testIBCHostAddress, crypto.Keccak256([]byte("other")), getProof(slot), "slot"},
+		{"no storage proof", root, testIBCHostAddress, slot, &ETHProof{AccountProofRLP: getProof(slot).AccountProofRLP}, "storage proof is empty"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := verifyStorageProof(c.root, c.address, c.slot, c.proof)
+			if err == nil {
+				t.Fatal("expected an error, but got nil")
+			}
+			if !strings.Contains(err.Error(), c.contains) {
+				t.Fatalf("expected an error containing %q, but got %v", c.contains, err)
+			}
+		})
+	}
+}
+
+func TestGetStorageProofOfSlotNotSet(t *testing.T) {
+	env := newTestEnv(t, 0)
+	slot := crypto.Keccak256([]byte("slot"))
+	env.shard.MineBlock()
+	// the slot is set only after the height
+	height := env.shard.BlockNumber()
+	env.shard.SetStorage(testIBCHostAddress, common.BytesToHash(slot), crypto.Keccak256Hash([]byte("value")))
+	env.shard.MineBlock()
+
+	proof, err := env.prover.getStorageProof(context.Background(), slot, new(big.Int).SetUint64(height))
+	if err != nil {
+		t.Fatalf("failed to get a non-membership proof: %v", err)
+	}
+	if value := env.provenValue(t, height, slot, proof); len(value) != 0 {
+		t.Fatalf("unexpected value of a slot which isn't set: %x", value)
+	}
+	proof, err = env.prover.getStorageProof(context.Background(), slot, new(big.Int).SetUint64(height+1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value := env.provenValue(t, height+1, slot, proof); len(value) == 0 {
+		t.Fatal("the value of a set slot must not be empty")
+	}
+}
@@ -8,19 +8,22 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
 	conntypes "github.com/cosmos/ibc-go/modules/core/03-connection/types"
 	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
 	committypes "github.com/cosmos/ibc-go/modules/core/23-commitment/types"
+	host "github.com/cosmos/ibc-go/modules/core/24-host"
 	"github.com/cosmos/ibc-go/modules/core/exported"
 	ibcexported "github.com/cosmos/ibc-go/modules/core/exported"
 	hmylctypes "github.com/datachainlab/ibc-harmony-client/modules/light-clients/harmony/types"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/block"
-	v3 "github.com/harmony-one/harmony/block/v3"
+	blockif "github.com/harmony-one/harmony/block/interface"
 	hmytypes "github.com/harmony-one/harmony/core/types"
 	rpcv2 "github.com/harmony-one/harmony/rpc/v2"
 	"github.com/harmony-one/harmony/shard"
@@ -36,14 +39,18 @@ type Prover struct {
 var _ core.ProverI = (*Prover)(nil)
 
 func NewProver(chain *Chain, config ProverConfig) (*Prover, error) {
-	beaconClient := NewHarmonyClient(chain.config.BeaconRpcAddr)
 	return &Prover{
 		chain:        chain,
-		beaconClient: beaconClient,
+		beaconClient: chain.beaconClient,
 		config:       config,
 	}, nil
 }
 
+// Config returns the config of the prover
+func (pr *Prover) Config() ProverConfig {
+	return pr.config
+}
+
 // GetChainID returns the chain ID
 func (pr *Prover) GetChainID() string {
 	return pr.chain.ChainID()
@@ -51,10 +58,11 @@ func (pr *Prover) GetChainID() string {
 
 // QueryLatestHeader returns the latest header from the chain
 func (pr *Prover) QueryLatestHeader() (out core.HeaderI, err error) {
-	if pr.chain.config.ShardId == 0 {
-		return pr.queryLatestHeaderForBeacon()
+	ctx := context.Background()
+	if pr.config.TracksBeacon(pr.chain.config.ShardId) {
+		return pr.queryLatestHeaderForBeacon(ctx)
 	} else {
-		return pr.queryLatestHeaderForShard()
+		return pr.queryLatestHeaderForShard(ctx)
 	}
 }
 
@@ -65,37 +73,48 @@ func (pr *Prover) GetLatestLightHeight() (int64, error) {
 
 // CreateMsgCreateClient creates a CreateClientMsg to this chain
 func (pr *Prover) CreateMsgCreateClient(clientID string, dstHeader core.HeaderI, signer sdk.AccAddress) (*clienttypes.MsgCreateClient, error) {
+	ctx := context.Background()
 	h, ok := dstHeader.(*hmylctypes.Header)
 	if !ok {
 		return nil, errors.New("dstHeader must be an harmony header")
 	}
-	beaconHeader, err := decodeV3(h.BeaconHeader.Header)
+	// a checkpoint given by the config takes precedence over the latest header
+	initialHeader, err := pr.queryInitialHeader(ctx)
+	if err != nil {
+		return nil, err
+	} else if initialHeader != nil {
+		h = initialHeader
+	}
+	beaconHeader, err := pr.chain.headers.decode(h.BeaconHeader.Header)
 	if err != nil {
 		return nil, err
 	}
 	if l := len(beaconHeader.ShardState()); l == 0 {
 		// The last beacon header of the previous epoch is needed
-		prevHeader, err := pr.queryEpochLastHeader(beaconHeader.Epoch().Uint64()-1, true)
+		prevHeader, err := pr.queryEpochLastHeader(ctx, beaconHeader.Epoch().Uint64()-1, true)
 		if err != nil {
 			return nil, err
 		}
 		h.EpochHeaders = append([]hmylctypes.BeaconHeader{*prevHeader.BeaconHeader}, h.EpochHeaders...)
-		beaconHeader, err = decodeV3(prevHeader.BeaconHeader.Header)
+		beaconHeader, err = pr.chain.headers.decode(prevHeader.BeaconHeader.Header)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	var targetHeader *v3.Header
-	if pr.chain.config.ShardId > 0 {
+	var targetHeader blockif.Header
+	if !pr.config.TracksBeacon(pr.chain.config.ShardId) {
 		var err error
-		targetHeader, err = decodeV3(h.ShardHeader)
+		targetHeader, err = pr.chain.headers.decode(h.ShardHeader)
 		if err != nil {
 			return nil, err
 		}
 	} else {
 		targetHeader = beaconHeader
 	}
+	if err := pr.checkClockDrift(targetHeader.Time()); err != nil {
+		return nil, err
+	}
 	var shardState shard.State
 	if err := rlp.DecodeBytes(beaconHeader.ShardState(), &shardState); err != nil {
 		return nil, err
@@ -124,11 +143,7 @@ func (pr *Prover) CreateMsgCreateClient(clientID string, dstHeader core.HeaderI,
 		Frozen:          false,
 	}
 
-	proof, err := decodeRLP(h.AccountProof)
-	if err != nil {
-		return nil, err
-	}
-	accountRLP, err := hmylctypes.VerifyProof(targetHeader.Root(), pr.chain.config.IBCHostAddress().Bytes(), proof)
+	accountRLP, err := verifyAccountProof(targetHeader.Root(), pr.chain.config.IBCHostAddress(), h.AccountProof)
 	if err != nil {
 		return nil, err
 	}
@@ -150,11 +165,19 @@ func (pr *Prover) CreateMsgCreateClient(clientID string, dstHeader core.HeaderI,
 
 // SetupHeader creates a new header based on a given header
 func (pr *Prover) SetupHeader(dstChain core.LightClientIBCQueryierI, baseSrcHeader core.HeaderI) (core.HeaderI, error) {
+	ctx := context.Background()
 	srcChain := pr.chain
 	header, ok := baseSrcHeader.(*hmylctypes.Header)
 	if !ok {
 		return nil, errors.New("invalid header type")
 	}
+	th, err := pr.decodeTargetHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	if err := pr.checkClockDrift(th.Time()); err != nil {
+		return nil, err
+	}
 	dsth, err := dstChain.GetLatestLightHeight()
 	if err != nil {
 		return nil, err
@@ -172,7 +195,7 @@ func (pr *Prover) SetupHeader(dstChain core.LightClientIBCQueryierI, baseSrcHead
 		return nil, err
 	}
 	trustedHeight := cs.GetLatestHeight().GetRevisionHeight()
-	trustedHeader, err := srcChain.client.FullHeader(context.Background(), trustedHeight)
+	trustedHeader, err := srcChain.client.FullHeader(ctx, trustedHeight)
 	if err != nil {
 		return nil, err
 	}
@@ -183,7 +206,7 @@ func (pr *Prover) SetupHeader(dstChain core.LightClientIBCQueryierI, baseSrcHead
 		prevEpoch := trustedHeader.Epoch.Uint64()
 		for i := 0; i < int(gapEpochSize); i++ {
 			// The last beacon header of epoch is needed to update committee
-			prevHeader, err := pr.queryEpochLastHeader(prevEpoch, true)
+			prevHeader, err := pr.queryEpochLastHeader(ctx, prevEpoch, true)
 			if err != nil {
 				return nil, err
 			}
@@ -195,6 +218,30 @@ func (pr *Prover) SetupHeader(dstChain core.LightClientIBCQueryierI, baseSrcHead
 	return header, nil
 }
 
+// decodeTargetHeader decodes the header which the client is updated to,
+// which is the shard header if it's given, or the beacon header otherwise
+func (pr *Prover) decodeTargetHeader(header *hmylctypes.Header) (blockif.Header, error) {
+	targetHeader := header.ShardHeader
+	if len(targetHeader) == 0 {
+		targetHeader = header.BeaconHeader.Header
+	}
+	return pr.chain.headers.decode(targetHeader)
+}
+
+// HeaderTime returns the time of a header, which becomes the timestamp of the consensus state it creates
+func (pr *Prover) HeaderTime(header core.HeaderI) (time.Time, error) {
+	h, ok := header.(*hmylctypes.Header)
+	if !ok {
+		return time.Time{}, errors.New("invalid header type")
+	}
+	th, err := pr.decodeTargetHeader(h)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// harmony block timestamps are in seconds
+	return time.Unix(th.Time().Int64(), 0), nil
+}
+
 // UpdateLightWithHeader updates a header on the light client and returns the header and height corresponding to the chain
 func (pr *Prover) UpdateLightWithHeader() (header core.HeaderI, provableHeight int64, queryableHeight int64, err error) {
 	h, err := pr.QueryLatestHeader()
@@ -210,6 +257,8 @@ func (pr *Prover) UpdateLightWithHeader() (header core.HeaderI, provableHeight i
 
 // QueryClientConsensusState returns the ClientConsensusState and its proof
 func (pr *Prover) QueryClientConsensusStateWithProof(height int64, dstClientConsHeight ibcexported.Height) (*clienttypes.QueryConsensusStateResponse, error) {
+	ctx := context.Background()
+	defer pr.chain.client.Pin(uint64(height))()
 	res, err := pr.chain.QueryClientConsensusState(height, dstClientConsHeight)
 	if err != nil {
 		return nil, err
@@ -219,7 +268,7 @@ func (pr *Prover) QueryClientConsensusStateWithProof(height int64, dstClientCons
 	if err != nil {
 		return nil, err
 	}
-	proof, err := pr.getStorageProof(hexKey(key), big.NewInt(height))
+	proof, err := pr.getStorageProof(ctx, key, big.NewInt(height))
 	if err != nil {
 		return nil, err
 	}
@@ -230,6 +279,8 @@ func (pr *Prover) QueryClientConsensusStateWithProof(height int64, dstClientCons
 
 // QueryClientStateWithProof returns the ClientState and its proof
 func (pr *Prover) QueryClientStateWithProof(height int64) (*clienttypes.QueryClientStateResponse, error) {
+	ctx := context.Background()
+	defer pr.chain.client.Pin(uint64(height))()
 	fmt.Println("-----QueryClientStateWithProof----")
 	res, err := pr.chain.QueryClientState(height)
 	if err != nil {
@@ -239,7 +290,7 @@ func (pr *Prover) QueryClientStateWithProof(height int64) (*clienttypes.QueryCli
 	if err != nil {
 		return nil, err
 	}
-	proof, err := pr.getStorageProof(hexKey(key), big.NewInt(height))
+	proof, err := pr.getStorageProof(ctx, key, big.NewInt(height))
 	if err != nil {
 		return nil, err
 	}
@@ -250,6 +301,8 @@ func (pr *Prover) QueryClientStateWithProof(height int64) (*clienttypes.QueryCli
 
 // QueryConnectionWithProof returns the Connection and its proof
 func (pr *Prover) QueryConnectionWithProof(height int64) (*conntypes.QueryConnectionResponse, error) {
+	ctx := context.Background()
+	defer pr.chain.client.Pin(uint64(height))()
 	res, err := pr.chain.QueryConnection(height)
 	if err != nil {
 		return nil, err
@@ -258,7 +311,7 @@ func (pr *Prover) QueryConnectionWithProof(height int64) (*conntypes.QueryConnec
 	if err != nil {
 		return nil, err
 	}
-	proof, err := pr.getStorageProof(hexKey(key), big.NewInt(height))
+	proof, err := pr.getStorageProof(ctx, key, big.NewInt(height))
 	if err != nil {
 		return nil, err
 	}
@@ -269,6 +322,8 @@ func (pr *Prover) QueryConnectionWithProof(height int64) (*conntypes.QueryConnec
 
 // QueryChannelWithProof returns the Channel and its proof
 func (pr *Prover) QueryChannelWithProof(height int64) (chanRes *chantypes.QueryChannelResponse, err error) {
+	ctx := context.Background()
+	defer pr.chain.client.Pin(uint64(height))()
 	res, err := pr.chain.QueryChannel(height)
 	if err != nil {
 		return nil, err
@@ -278,7 +333,7 @@ func (pr *Prover) QueryChannelWithProof(height int64) (chanRes *chantypes.QueryC
 	if err != nil {
 		return nil, err
 	}
-	proof, err := pr.getStorageProof(hexKey(key), big.NewInt(height))
+	proof, err := pr.getStorageProof(ctx, key, big.NewInt(height))
 	if err != nil {
 		return nil, err
 	}
@@ -289,6 +344,8 @@ func (pr *Prover) QueryChannelWithProof(height int64) (chanRes *chantypes.QueryC
 
 // QueryPacketCommitmentWithProof returns the packet commitment and its proof
 func (pr *Prover) QueryPacketCommitmentWithProof(height int64, seq uint64) (comRes *chantypes.QueryPacketCommitmentResponse, err error) {
+	ctx := context.Background()
+	defer pr.chain.client.Pin(uint64(height))()
 	res, err := pr.chain.QueryPacketCommitment(height, seq)
 	if err != nil {
 		return nil, err
@@ -298,7 +355,7 @@ func (pr *Prover) QueryPacketCommitmentWithProof(height int64, seq uint64) (comR
 	if err != nil {
 		return nil, err
 	}
-	proof, err := pr.getStorageProof(hexKey(key), big.NewInt(height))
+	proof, err := pr.getStorageProof(ctx, key, big.NewInt(height))
 	if err != nil {
 		return nil, err
 	}
@@ -309,6 +366,8 @@ func (pr *Prover) QueryPacketCommitmentWithProof(height int64, seq uint64) (comR
 
 // QueryPacketAcknowledgementCommitmentWithProof returns the packet acknowledgement commitment and its proof
 func (pr *Prover) QueryPacketAcknowledgementCommitmentWithProof(height int64, seq uint64) (ackRes *chantypes.QueryPacketAcknowledgementResponse, err error) {
+	ctx := context.Background()
+	defer pr.chain.client.Pin(uint64(height))()
 	res, err := pr.chain.QueryPacketAcknowledgementCommitment(height, seq)
 	if err != nil {
 		return nil, err
@@ -318,7 +377,25 @@ func (pr *Prover) QueryPacketAcknowledgementCommitmentWithProof(height int64, se
 	if err != nil {
 		return nil, err
 	}
-	proof, err := pr.getStorageProof(hexKey(key), big.NewInt(height))
+	proof, err := pr.getStorageProof(ctx, key, big.NewInt(height))
+	if err != nil {
+		return nil, err
+	}
+	res.Proof = proof
+	res.ProofHeight = clienttypes.NewHeight(0, uint64(height))
+	return res, nil
+}
+
+// QueryNextSequenceRecvWithProof returns the next sequence to be received on the channel and its proof
+func (pr *Prover) QueryNextSequenceRecvWithProof(height int64) (*chantypes.QueryNextSequenceReceiveResponse, error) {
+	ctx := context.Background()
+	defer pr.chain.client.Pin(uint64(height))()
+	res, err := pr.chain.QueryNextSequenceRecv(height)
+	if err != nil {
+		return nil, err
+	}
+	path := pr.chain.Path()
+	proof, err := pr.getStorageProof(ctx, nextSequenceRecvCommitmentSlot(path.PortID, path.ChannelID), big.NewInt(height))
 	if err != nil {
 		return nil, err
 	}
@@ -327,36 +404,53 @@ func (pr *Prover) QueryPacketAcknowledgementCommitmentWithProof(height int64, se
 	return res, nil
 }
 
-func (pr *Prover) getAccountProof(client *Client, key []byte, blockNumber *big.Int) ([]byte, error) {
-	ethProof, err := getETHProof(client, pr.chain.config.IBCHostAddress(), key, blockNumber)
+// getAccountProof returns the account proof of IBCHost after verifying it against the given state root
+func (pr *Prover) getAccountProof(ctx context.Context, client *Client, stateRoot common.Hash, blockNumber *big.Int) ([]byte, error) {
+	address := pr.chain.config.IBCHostAddress()
+	ethProof, err := getETHProof(ctx, client, address, nil, blockNumber)
 	if err != nil {
 		return nil, err
 	}
+	if _, err := verifyAccountProof(stateRoot, address, ethProof.AccountProofRLP); err != nil {
+		return nil, fmt.Errorf("failed to verify the account proof at height %v: %w", blockNumber, err)
+	}
 	return ethProof.AccountProofRLP, nil
 }
 
-func (pr *Prover) getStorageProof(key []byte, blockNumber *big.Int) ([]byte, error) {
-	ethProof, err := getETHProof(pr.chain.client, pr.chain.config.IBCHostAddress(), key, blockNumber)
+// getStorageProof returns the storage proof of the given slot of IBCHost
+// after verifying it against the state root of the shard header at the given height.
+// A non-membership proof of a slot that isn't set is returned as well.
+// Since the state root is fetched from the same node as the proof, the verification only guards against
+// inconsistent responses such as a proof at a wrong height, not against a malicious node,
+// which is left to the light client on the counterparty.
+func (pr *Prover) getStorageProof(ctx context.Context, slot []byte, blockNumber *big.Int) ([]byte, error) {
+	address := pr.chain.config.IBCHostAddress()
+	ethProof, err := getETHProof(ctx, pr.chain.client, address, hexKey(slot), blockNumber)
 	if err != nil {
 		return nil, err
 	}
-	if len(ethProof.StorageProofRLP) == 0 {
-		return nil, errors.New("storage proof is empty")
+	header, err := pr.chain.client.FullHeader(ctx, blockNumber.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := verifyStorageProof(header.StateRoot, address, slot, ethProof); err != nil {
+		return nil, fmt.Errorf("failed to verify the storage proof of slot 0x%x at height %v: %w", slot, blockNumber, err)
 	}
 	return ethProof.StorageProofRLP[0], nil
 }
 
 // When skipsShardHeader is true, the return header does not contain a shard header.
-func (pr *Prover) queryEpochLastHeader(epoch uint64, skipsShardHeader bool) (*hmylctypes.Header, error) {
-	height, err := pr.beaconClient.EpochLastBlockNumber(context.Background(), epoch)
+// SetupHeader always skips it, because only the beacon headers are used as epoch headers.
+func (pr *Prover) queryEpochLastHeader(ctx context.Context, epoch uint64, skipsShardHeader bool) (*hmylctypes.Header, error) {
+	height, err := pr.beaconClient.EpochLastBlockNumber(ctx, epoch)
 	if err != nil {
 		return nil, err
 	}
-	beaconHeader, err := pr.beaconClient.FullHeader(context.Background(), height)
+	beaconHeader, err := pr.beaconClient.FullHeader(ctx, height)
 	if err != nil {
 		return nil, err
 	}
-	nextHeader, err := pr.beaconClient.FullHeader(context.Background(), height+1)
+	nextHeader, err := pr.beaconClient.FullHeader(ctx, height+1)
 	if err != nil {
 		return nil, err
 	}
@@ -381,15 +475,16 @@ func (pr *Prover) queryEpochLastHeader(epoch uint64, skipsShardHeader bool) (*hm
 				}
 			}
 			if found {
-				shardHeader, err := pr.chain.client.FullHeader(context.Background(), crossLink.BlockNumberF.Uint64())
+				// assigned to the outer shardHeader, so that the header contains it and its account proof
+				shardHeader, err = pr.chain.client.FullHeader(ctx, crossLink.BlockNumberF.Uint64())
 				if err != nil {
 					return nil, err
 				}
-				shv3, err := convertHeader(shardHeader)
+				sh, err := pr.chain.headers.convert(shardHeader)
 				if err != nil {
 					return nil, err
 				}
-				b := block.Header{Header: shv3}
+				b := block.Header{Header: sh}
 				if !bytes.Equal(crossLink.HashF.Bytes(), b.Hash().Bytes()) {
 					return nil, fmt.Errorf("invalid cross link on beacon block %d, shard block %d. expected: %s, got: %s",
 						beaconHeader.Number.Uint64(), shardHeader.Number.Uint64(), crossLink.HashF.Hex(), b.Hash().Hex())
@@ -398,18 +493,18 @@ func (pr *Prover) queryEpochLastHeader(epoch uint64, skipsShardHeader bool) (*hm
 		}
 	}
 
-	bhRLP, err := encodeAsV3(beaconHeader)
+	bhRLP, err := pr.chain.headers.encode(beaconHeader)
 	if err != nil {
 		return nil, err
 	}
 	var shRLP []byte = nil
 	var proof []byte = nil
 	if shardHeader != nil {
-		shRLP, err = encodeAsV3(shardHeader)
+		shRLP, err = pr.chain.headers.encode(shardHeader)
 		if err != nil {
 			return nil, err
 		}
-		proof, err = pr.getAccountProof(pr.chain.client, nil, shardHeader.Number)
+		proof, err = pr.getAccountProof(ctx, pr.chain.client, shardHeader.StateRoot, shardHeader.Number)
 		if err != nil {
 			return nil, err
 		}
@@ -426,25 +521,66 @@ func (pr *Prover) queryEpochLastHeader(epoch uint64, skipsShardHeader bool) (*hm
 	}, nil
 }
 
-func (pr *Prover) queryLatestHeaderForBeacon() (out core.HeaderI, err error) {
-	height, err := pr.beaconClient.BlockNumber(context.Background())
+// queryInitialHeader returns the header at the checkpoint given by the config.
+// If no checkpoint is given, it returns nil.
+func (pr *Prover) queryInitialHeader(ctx context.Context) (*hmylctypes.Header, error) {
+	var height uint64
+	switch {
+	case pr.config.InitialHeight > 0:
+		height = pr.config.InitialHeight
+	case pr.config.InitialEpoch > 0:
+		var err error
+		height, err = pr.beaconClient.EpochLastBlockNumber(ctx, pr.config.InitialEpoch)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+	if pr.config.TracksBeacon(pr.chain.config.ShardId) {
+		return pr.queryHeaderForBeacon(ctx, height)
+	}
+	return pr.queryHeaderForShard(ctx, height)
+}
+
+// checkClockDrift returns an error if the given header timestamp is ahead of the local clock by more than the max clock drift.
+// The client on the counterparty doesn't enforce max_clock_drift, so this relayer-side check is the only one.
+func (pr *Prover) checkClockDrift(timestamp *big.Int) error {
+	drift, err := pr.config.MaxClockDriftDuration()
+	if err != nil {
+		return err
+	}
+	if t := time.Unix(timestamp.Int64(), 0); t.After(time.Now().Add(drift)) {
+		return fmt.Errorf("header timestamp %v is ahead of the local clock by more than %v", t, drift)
+	}
+	return nil
+}
+
+func (pr *Prover) queryLatestHeaderForBeacon(ctx context.Context) (out core.HeaderI, err error) {
+	height, err := pr.beaconClient.BlockNumber(ctx)
 	if err != nil {
 		return nil, err
 	}
-	beaconHeader, err := pr.beaconClient.FullHeader(context.Background(), height-1)
+	// For getting commitSig and commitBitmap from the next height
+	return pr.queryHeaderForBeacon(ctx, height-1)
+}
+
+// queryHeaderForBeacon returns the beacon header at the given height
+func (pr *Prover) queryHeaderForBeacon(ctx context.Context, height uint64) (*hmylctypes.Header, error) {
+	beaconHeader, err := pr.beaconClient.FullHeader(ctx, height)
 	if err != nil {
 		return nil, err
 	}
-	nextHeader, err := pr.beaconClient.FullHeader(context.Background(), height)
+	nextHeader, err := pr.beaconClient.FullHeader(ctx, height+1)
 	if err != nil {
 		return nil, err
 	}
 
-	bhRLP, err := encodeAsV3(beaconHeader)
+	bhRLP, err := pr.chain.headers.encode(beaconHeader)
 	if err != nil {
 		return nil, err
 	}
-	proof, err := pr.getAccountProof(pr.beaconClient, nil, beaconHeader.Number)
+	proof, err := pr.getAccountProof(ctx, pr.beaconClient, beaconHeader.StateRoot, beaconHeader.Number)
 	if err != nil {
 		return nil, err
 	}
@@ -461,19 +597,23 @@ func (pr *Prover) queryLatestHeaderForBeacon() (out core.HeaderI, err error) {
 	return header, nil
 }
 
-func (pr *Prover) queryLatestHeaderForShard() (out core.HeaderI, err error) {
-	height, err := pr.beaconClient.BlockNumber(context.Background())
+func (pr *Prover) queryLatestHeaderForShard(ctx context.Context) (out core.HeaderI, err error) {
+	height, err := pr.beaconClient.BlockNumber(ctx)
 	if err != nil {
 		return nil, err
 	}
 	// For getting commitSig and commitBitmap from the next height
-	height -= 1
-	var header *hmylctypes.Header
+	return pr.queryHeaderForShard(ctx, height-1)
+}
+
+// queryHeaderForShard returns the latest crosslinked pair of a beacon header and a shard header
+// whose beacon height is less than or equal to the given height
+func (pr *Prover) queryHeaderForShard(ctx context.Context, height uint64) (*hmylctypes.Header, error) {
 	// Find a crosslinked header pair.
-	// Decrease the beacon height one by one until it is found.
-	// TODO consider iterate limit
+	// Decrease the beacon height one by one until it is found,
+	// or the shard state becomes out of the pruning window of the endpoints.
 	for ; height > 0; height-- {
-		beaconHeader, err := pr.beaconClient.FullHeader(context.Background(), height)
+		beaconHeader, err := pr.beaconClient.FullHeader(ctx, height)
 		if err != nil {
 			return nil, err
 		}
@@ -499,42 +639,45 @@ func (pr *Prover) queryLatestHeaderForShard() (out core.HeaderI, err error) {
 		if crossLinkIndex == -1 {
 			continue
 		}
+		if shardHeight := crossLink.BlockNumberF.Uint64(); !pr.chain.client.CanServe(shardHeight) {
+			return nil, fmt.Errorf("the latest crosslinked shard block %d is out of the pruning window, and no archive endpoint is available", shardHeight)
+		}
 
-		shardHeader, err := pr.chain.client.FullHeader(context.Background(), crossLink.BlockNumberF.Uint64())
+		shardHeader, err := pr.chain.client.FullHeader(ctx, crossLink.BlockNumberF.Uint64())
 		if err != nil {
 			return nil, err
 		}
-		shv3, err := convertHeader(shardHeader)
+		sh, err := pr.chain.headers.convert(shardHeader)
 		if err != nil {
 			return nil, err
 		}
-		b := block.Header{Header: shv3}
+		b := block.Header{Header: sh}
 		if !bytes.Equal(crossLink.HashF.Bytes(), b.Hash().Bytes()) {
 			return nil, fmt.Errorf("invalid cross link on beacon block %d, shard block %d. expected: %s, got: %s",
 				beaconHeader.Number.Uint64(), shardHeader.Number.Uint64(), crossLink.HashF.Hex(), b.Hash().Hex())
 		}
-		nextBeaconHeader, err := pr.beaconClient.FullHeader(context.Background(), beaconHeader.Number.Uint64()+1)
+		nextBeaconHeader, err := pr.beaconClient.FullHeader(ctx, beaconHeader.Number.Uint64()+1)
 		if err != nil {
 			return nil, err
 		}
 
-		bhRLP, err := encodeAsV3(beaconHeader)
+		bhRLP, err := pr.chain.headers.encode(beaconHeader)
 		if err != nil {
 			return nil, err
 		}
 		var shRLP []byte = nil
 		var proof []byte = nil
 		if shardHeader != nil {
-			shRLP, err = encodeAsV3(shardHeader)
+			shRLP, err = pr.chain.headers.encode(shardHeader)
 			if err != nil {
 				return nil, err
 			}
-			proof, err = pr.getAccountProof(pr.chain.client, nil, shardHeader.Number)
+			proof, err = pr.getAccountProof(ctx, pr.chain.client, shardHeader.StateRoot, shardHeader.Number)
 			if err != nil {
 				return nil, err
 			}
 		}
-		header = &hmylctypes.Header{
+		return &hmylctypes.Header{
 			ShardHeader: shRLP,
 			BeaconHeader: &hmylctypes.BeaconHeader{
 				Header:       bhRLP,
@@ -543,18 +686,18 @@ func (pr *Prover) queryLatestHeaderForShard() (out core.HeaderI, err error) {
 			},
 			CrossLinkIndex: uint32(crossLinkIndex),
 			AccountProof:   proof,
-		}
-		break
+		}, nil
 	}
-	return header, nil
+	return nil, fmt.Errorf("no cross link for shard %d found", pr.chain.config.ShardId)
 }
 
-func getETHProof(client *Client, address common.Address, key []byte, blockNumber *big.Int) (*ETHProof, error) {
+func getETHProof(ctx context.Context, client *Client, address common.Address, key []byte, blockNumber *big.Int) (*ETHProof, error) {
 	var k [][]byte = nil
 	if len(key) > 0 {
 		k = [][]byte{key}
 	}
 	proof, err := client.GetETHProof(
+		ctx,
 		address,
 		k,
 		blockNumber,
@@ -565,57 +708,11 @@ func getETHProof(client *Client, address common.Address, key []byte, blockNumber
 	return proof, nil
 }
 
-func encodeAsV3(bh *rpcv2.BlockHeader) ([]byte, error) {
-	buf := &bytes.Buffer{}
-	v3h, err := convertHeader(bh)
-	if err != nil {
-		return nil, err
-	}
-	if err := v3h.EncodeRLP(buf); err != nil {
-		return nil, err
-	}
-	return buf.Bytes(), nil
-}
-
-func decodeV3(header []byte) (*v3.Header, error) {
-	stream := rlp.NewStream(bytes.NewBuffer(header), 0)
-	sh := v3.NewHeader()
-	if err := sh.DecodeRLP(stream); err != nil {
-		return nil, err
-	}
-	return sh, nil
-}
-
-func convertHeader(bh *rpcv2.BlockHeader) (*v3.Header, error) {
-	//h := block.Header{Header: v3.NewHeader()}
-	h := v3.NewHeader()
-	h.SetParentHash(bh.ParentHash)
-	h.SetCoinbase(common.HexToAddress(bh.Miner))
-	h.SetRoot(bh.StateRoot)
-	h.SetTxHash(bh.TransactionsRoot)
-	h.SetReceiptHash(bh.ReceiptsRoot)
-	h.SetOutgoingReceiptHash(bh.OutgoingReceiptsRoot)
-	h.SetIncomingReceiptHash(bh.IncomingReceiptsRoot)
-	h.SetBloom(bh.LogsBloom)
-	h.SetNumber(bh.Number)
-	h.SetGasLimit(bh.GasLimit)
-	h.SetGasUsed(bh.GasUsed)
-	h.SetTime(bh.Timestamp)
-	h.SetExtra(bh.ExtraData)
-	h.SetMixDigest(bh.MixHash)
-	h.SetViewID(bh.ViewID)
-	h.SetEpoch(bh.Epoch)
-	h.SetShardID(bh.ShardID)
-	var commitSig [96]byte
-	copy(commitSig[:], bh.LastCommitSignature)
-	h.SetLastCommitSignature(commitSig)
-	h.SetLastCommitBitmap(bh.LastCommitBitmap)
-	h.SetVrf(bh.Vrf)
-	h.SetVdf(bh.Vdf)
-	h.SetShardState(bh.ShardState)
-	h.SetCrossLinks(bh.CrossLink)
-	h.SetSlashes(bh.Slashes)
-	return h, nil
+// nextSequenceRecvCommitmentSlot returns the storage slot of the next sequence to be received on the channel,
+// which is stored in the commitments mapping of IBCHost in the same way as the other commitments
+func nextSequenceRecvCommitmentSlot(portID, channelID string) []byte {
+	key := crypto.Keccak256([]byte(host.NextSequenceRecvPath(portID, channelID)))
+	return crypto.Keccak256(key, common.Hash{}.Bytes())
 }
 
 func hexKey(key []byte) []byte {
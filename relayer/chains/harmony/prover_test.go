@@ -0,0 +1,507 @@
+package harmony
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	committypes "github.com/cosmos/ibc-go/modules/core/23-commitment/types"
+	"github.com/cosmos/ibc-go/modules/core/exported"
+	tmclient "github.com/cosmos/ibc-go/modules/light-clients/07-tendermint/types"
+	hmylctypes "github.com/datachainlab/ibc-harmony-client/modules/light-clients/harmony/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/ibchost"
+	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/harmony/fakenode"
+	"github.com/mapdev33/yui-relayer/core"
+)
+
+const testBlocksPerEpoch = 4
+
+var (
+	testIBCHostAddress    = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	testIBCHandlerAddress = common.HexToAddress("0x1000000000000000000000000000000000000002")
+	testICS20BankAddress  = common.HexToAddress("0x1000000000000000000000000000000000000003")
+	testTransferAddress   = common.HexToAddress("0x1000000000000000000000000000000000000004")
+	testTokenAddress      = common.HexToAddress("0x1000000000000000000000000000000000000005")
+)
+
+type testEnv struct {
+	beacon *fakenode.Node
+	shard  *fakenode.Node
+	chain  *Chain
+	prover *Prover
+}
+
+// newTestEnv returns a chain and its prover connected to fake nodes.
+// If shardID is 0, the beacon node serves as the shard node.
+func newTestEnv(t *testing.T, shardID uint32) *testEnv {
+	beacon := fakenode.New(0, testBlocksPerEpoch)
+	t.Cleanup(beacon.Close)
+	shard := beacon
+	if shardID != 0 {
+		shard = fakenode.New(shardID, testBlocksPerEpoch)
+		t.Cleanup(shard.Close)
+	}
+	// an account of IBCHost must exist to get an account proof
+	shard.SetStorage(testIBCHostAddress, common.Hash{}, common.BytesToHash([]byte{1}))
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain, err := NewChain(ChainConfig{
+		ChainId:                  "ibc1",
+		HarmonyChainId:           "localnet",
+		ShardId:                  shardID,
+		ShardRpcAddr:             shard.URL(),
+		BeaconRpcAddr:            beacon.URL(),
+		ShardPrivateKey:          hex.EncodeToString(crypto.FromECDSA(key)),
+		IbcHostAddress:           testIBCHostAddress.Hex(),
+		IbcHandlerAddress:        testIBCHandlerAddress.Hex(),
+		Ics20BankAddress:         testICS20BankAddress.Hex(),
+		Ics20TransferBankAddress: testTransferAddress.Hex(),
+		TokenAddress:             testTokenAddress.Hex(),
+		GasLimit:                 6721975,
+		GasPrice:                 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := chain.Init(t.TempDir(), time.Minute, makeTestCodec(), false); err != nil {
+		t.Fatal(err)
+	}
+	if err := chain.SetPath(&core.PathEnd{
+		ChainID:      "ibc1",
+		ClientID:     "07-tendermint-0",
+		ConnectionID: "connection-0",
+		ChannelID:    "channel-0",
+		PortID:       "transfer",
+		Order:        "unordered",
+		Version:      "ics20-1",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	prover, err := NewProver(chain, ProverConfig{TrustingPeriod: "24h"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testEnv{
+		beacon: beacon,
+		shard:  shard,
+		chain:  chain,
+		prover: prover,
+	}
+}
+
+func makeTestCodec() codec.ProtoCodecMarshaler {
+	registry := codectypes.NewInterfaceRegistry()
+	clienttypes.RegisterInterfaces(registry)
+	tmclient.RegisterInterfaces(registry)
+	RegisterInterfaces(registry)
+	return codec.NewProtoCodec(registry)
+}
+
+// mineBlocks mines blocks until the latest block number reaches the given height
+func mineBlocks(node *fakenode.Node, height uint64) {
+	for node.BlockNumber() < height {
+		node.MineBlock()
+	}
+}
+
+// provenValue verifies a storage proof of IBCHost against the state root of the shard block at the given height,
+// and returns the proven value
+func (env *testEnv) provenValue(t *testing.T, height uint64, slot []byte, storageProof []byte) []byte {
+	root := env.shard.Header(height).Root()
+	accountProof, err := env.prover.getAccountProof(context.Background(), env.chain.client, root, new(big.Int).SetUint64(height))
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := verifyStorageProof(root, testIBCHostAddress, slot, &ETHProof{
+		AccountProofRLP: accountProof,
+		StorageProofRLP: [][]byte{storageProof},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func TestQueryLatestHeaderForBeacon(t *testing.T) {
+	env := newTestEnv(t, 0)
+	mineBlocks(env.beacon, 5)
+
+	h, err := env.prover.QueryLatestHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := h.(*hmylctypes.Header)
+	if len(header.ShardHeader) != 0 {
+		t.Fatal("a beacon header must not contain a shard header")
+	}
+	bh, err := env.chain.headers.decode(header.BeaconHeader.Header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the latest header is the parent of the latest block, which has the commit signature of it
+	if bh.Number().Uint64() != 4 {
+		t.Fatalf("unexpected height: %v", bh.Number())
+	}
+	next := env.beacon.Header(5)
+	if sig := next.LastCommitSignature(); !bytes.Equal(header.BeaconHeader.CommitSig, sig[:]) {
+		t.Fatal("unexpected commit signature")
+	}
+	proof, err := decodeRLP(header.AccountProof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hmylctypes.VerifyProof(bh.Root(), testIBCHostAddress.Bytes(), proof); err != nil {
+		t.Fatalf("failed to verify the account proof: %v", err)
+	}
+}
+
+func TestQueryLatestHeaderForShard(t *testing.T) {
+	env := newTestEnv(t, 1)
+	mineBlocks(env.shard, 3)
+	mineBlocks(env.beacon, 1)
+	env.beacon.MineBlock(env.shard.CrossLink(2), env.shard.CrossLink(3))
+	mineBlocks(env.beacon, 4)
+
+	h, err := env.prover.QueryLatestHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := h.(*hmylctypes.Header)
+	bh, err := env.chain.headers.decode(header.BeaconHeader.Header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bh.Number().Uint64() != 2 {
+		t.Fatalf("the beacon header must be the one including the cross links: %v", bh.Number())
+	}
+	sh, err := env.chain.headers.decode(header.ShardHeader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the latest cross link is used
+	if sh.Number().Uint64() != 3 || header.CrossLinkIndex != 1 {
+		t.Fatalf("unexpected shard header: height=%v index=%v", sh.Number(), header.CrossLinkIndex)
+	}
+	proof, err := decodeRLP(header.AccountProof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hmylctypes.VerifyProof(sh.Root(), testIBCHostAddress.Bytes(), proof); err != nil {
+		t.Fatalf("failed to verify the account proof: %v", err)
+	}
+}
+
+func TestQueryLatestHeaderForShardWithoutCrossLink(t *testing.T) {
+	env := newTestEnv(t, 1)
+	mineBlocks(env.shard, 3)
+	mineBlocks(env.beacon, 3)
+
+	_, err := env.prover.QueryLatestHeader()
+	if err == nil || !strings.Contains(err.Error(), "no cross link") {
+		t.Fatalf("expected an error for missing cross links, but got %v", err)
+	}
+}
+
+// testCounterparty is a counterparty chain which only returns a client state
+type testCounterparty struct {
+	core.LightClientIBCQueryierI
+	clientState exported.ClientState
+}
+
+func (c testCounterparty) GetLatestLightHeight() (int64, error) {
+	return -1, nil
+}
+
+func (c testCounterparty) QueryClientState(height int64) (*clienttypes.QueryClientStateResponse, error) {
+	any, err := clienttypes.PackClientState(c.clientState)
+	if err != nil {
+		return nil, err
+	}
+	return clienttypes.NewQueryClientStateResponse(any, nil, clienttypes.NewHeight(0, 0)), nil
+}
+
+func TestSetupHeader(t *testing.T) {
+	env := newTestEnv(t, 0)
+	mineBlocks(env.beacon, 3*testBlocksPerEpoch)
+
+	latest, err := env.prover.QueryLatestHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counterparty := testCounterparty{
+		clientState: &hmylctypes.ClientState{
+			LatestEpoch:  0,
+			LatestHeight: clienttypes.NewHeight(0, 2),
+		},
+	}
+	h, err := env.prover.SetupHeader(counterparty, latest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := h.(*hmylctypes.Header)
+	// the latest header is in epoch 2, so the last headers of epoch 0 and 1 are needed to update the committee
+	if len(header.EpochHeaders) != 2 {
+		t.Fatalf("unexpected number of epoch headers: %v", len(header.EpochHeaders))
+	}
+	for i, eh := range header.EpochHeaders {
+		bh, err := env.chain.headers.decode(eh.Header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := env.beacon.EpochLastBlockNumber(uint64(i)); bh.Number().Uint64() != expected {
+			t.Fatalf("epoch header %d: expected height %v, but got %v", i, expected, bh.Number())
+		}
+	}
+}
+
+func TestHeaderTime(t *testing.T) {
+	env := newTestEnv(t, 0)
+	mineBlocks(env.beacon, 5)
+
+	latest, err := env.prover.QueryLatestHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tm, err := env.prover.HeaderTime(latest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the fake node produces a block every 2 seconds
+	height := latest.GetHeight().GetRevisionHeight()
+	if expected := time.Unix(int64(1600000000+2*height), 0); !tm.Equal(expected) {
+		t.Fatalf("unexpected time of header %d: %v, want %v", height, tm, expected)
+	}
+	if _, err := env.prover.HeaderTime(nil); err == nil {
+		t.Fatal("expected an error for an invalid header type")
+	}
+}
+
+func TestSetupHeaderInSameEpoch(t *testing.T) {
+	env := newTestEnv(t, 0)
+	mineBlocks(env.beacon, testBlocksPerEpoch-1)
+
+	latest, err := env.prover.QueryLatestHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counterparty := testCounterparty{
+		clientState: &hmylctypes.ClientState{
+			LatestHeight: clienttypes.NewHeight(0, 1),
+		},
+	}
+	h, err := env.prover.SetupHeader(counterparty, latest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(h.(*hmylctypes.Header).EpochHeaders); l != 0 {
+		t.Fatalf("no epoch headers are needed, but got %v", l)
+	}
+}
+
+// ibcHostCallHandler returns results of IBCHost calls with the given state
+func ibcHostCallHandler(t *testing.T, cdc codec.ProtoCodecMarshaler, clientState exported.ClientState, consensusState exported.ConsensusState, commitment [32]byte) fakenode.CallHandler {
+	hostABI, err := abi.JSON(strings.NewReader(ibchost.IbchostABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientStateBytes, err := cdc.MarshalInterface(clientState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	consensusStateBytes, err := cdc.MarshalInterface(consensusState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return func(input []byte) ([]byte, error) {
+		method, err := hostABI.MethodById(input[:4])
+		if err != nil {
+			return nil, err
+		}
+		switch method.Name {
+		case "getClientState":
+			return method.Outputs.Pack(clientStateBytes, true)
+		case "getConsensusState":
+			return method.Outputs.Pack(consensusStateBytes, true)
+		case "getConnection":
+			return method.Outputs.Pack(ibchost.ConnectionEndData{ClientId: "07-tendermint-0"}, true)
+		case "getChannel":
+			return method.Outputs.Pack(ibchost.ChannelData{Version: "ics20-1"}, true)
+		case "getPacketCommitment", "getPacketAcknowledgementCommitment":
+			return method.Outputs.Pack(commitment, true)
+		case "getNextSequenceRecv":
+			return method.Outputs.Pack(uint64(1))
+		default:
+			return nil, fmt.Errorf("unexpected call: %v", method.Name)
+		}
+	}
+}
+
+func TestQueryWithProof(t *testing.T) {
+	env := newTestEnv(t, 0)
+	cdc := env.chain.Codec()
+	path := env.chain.Path()
+	consensusHeight := clienttypes.NewHeight(0, 10)
+	clientState := &tmclient.ClientState{ChainId: "ibc0", LatestHeight: consensusHeight}
+	consensusState := &tmclient.ConsensusState{
+		Timestamp: time.Unix(1600000000, 0).UTC(),
+		Root:      committypes.NewMerkleRoot([]byte("root")),
+	}
+	commitment := crypto.Keccak256Hash([]byte("commitment"))
+	env.beacon.HandleCall(testIBCHostAddress, ibcHostCallHandler(t, cdc, clientState, consensusState, commitment))
+
+	cases := []struct {
+		name  string
+		slot  func() ([]byte, error)
+		query func(height int64) ([]byte, clienttypes.Height, error)
+	}{
+		{
+			"client state",
+			func() ([]byte, error) { return hmylctypes.ClientStateCommitmentSlot(path.ClientID) },
+			func(height int64) ([]byte, clienttypes.Height, error) {
+				res, err := env.prover.QueryClientStateWithProof(height)
+				if err != nil {
+					return nil, clienttypes.Height{}, err
+				}
+				return res.Proof, res.ProofHeight, nil
+			},
+		},
+		{
+			"consensus state",
+			func() ([]byte, error) { return hmylctypes.ConsensusStateCommitmentSlot(path.ClientID, consensusHeight) },
+			func(height int64) ([]byte, clienttypes.Height, error) {
+				res, err := env.prover.QueryClientConsensusStateWithProof(height, consensusHeight)
+				if err != nil {
+					return nil, clienttypes.Height{}, err
+				}
+				return res.Proof, res.ProofHeight, nil
+			},
+		},
+		{
+			"connection",
+			func() ([]byte, error) { return hmylctypes.ConnectionCommitmentSlot(path.ConnectionID) },
+			func(height int64) ([]byte, clienttypes.Height, error) {
+				res, err := env.prover.QueryConnectionWithProof(height)
+				if err != nil {
+					return nil, clienttypes.Height{}, err
+				}
+				return res.Proof, res.ProofHeight, nil
+			},
+		},
+		{
+			"channel",
+			func() ([]byte, error) { return hmylctypes.ChannelCommitmentSlot(path.PortID, path.ChannelID) },
+			func(height int64) ([]byte, clienttypes.Height, error) {
+				res, err := env.prover.QueryChannelWithProof(height)
+				if err != nil {
+					return nil, clienttypes.Height{}, err
+				}
+				return res.Proof, res.ProofHeight, nil
+			},
+		},
+		{
+			"packet commitment",
+			func() ([]byte, error) { return hmylctypes.PacketCommitmentSlot(path.PortID, path.ChannelID, 1) },
+			func(height int64) ([]byte, clienttypes.Height, error) {
+				res, err := env.prover.QueryPacketCommitmentWithProof(height, 1)
+				if err != nil {
+					return nil, clienttypes.Height{}, err
+				}
+				return res.Proof, res.ProofHeight, nil
+			},
+		},
+		{
+			"packet acknowledgement commitment",
+			func() ([]byte, error) {
+				return hmylctypes.PacketAcknowledgementCommitmentSlot(path.PortID, path.ChannelID, 1)
+			},
+			func(height int64) ([]byte, clienttypes.Height, error) {
+				res, err := env.prover.QueryPacketAcknowledgementCommitmentWithProof(height, 1)
+				if err != nil {
+					return nil, clienttypes.Height{}, err
+				}
+				return res.Proof, res.ProofHeight, nil
+			},
+		},
+		{
+			"next sequence recv",
+			func() ([]byte, error) {
+				return ibcHostCommitmentSlot("nextSequenceRecv/ports/" + path.PortID + "/channels/" + path.ChannelID), nil
+			},
+			func(height int64) ([]byte, clienttypes.Height, error) {
+				res, err := env.prover.QueryNextSequenceRecvWithProof(height)
+				if err != nil {
+					return nil, clienttypes.Height{}, err
+				}
+				return res.Proof, res.ProofHeight, nil
+			},
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			slot, err := c.slot()
+			if err != nil {
+				t.Fatal(err)
+			}
+			value := crypto.Keccak256Hash([]byte(c.name))
+			env.beacon.SetStorage(testIBCHostAddress, common.BytesToHash(slot), value)
+			height := env.beacon.MineBlock().Number().Uint64()
+			// the storage at the proof height must not be affected by later blocks
+			env.beacon.SetStorage(testIBCHostAddress, common.BytesToHash(slot), common.Hash{})
+			env.beacon.MineBlock()
+
+			proof, proofHeight, err := c.query(int64(height))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if proofHeight.GetRevisionHeight() != height {
+				t.Fatalf("unexpected proof height: %v", proofHeight)
+			}
+			expected, err := rlp.EncodeToBytes(bytes.TrimLeft(value[:], "\x00"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := env.provenValue(t, height, slot, proof); !bytes.Equal(got, expected) {
+				t.Fatalf("case %d: unexpected proven value: %x", i, got)
+			}
+		})
+	}
+}
+
+// ibcHostCommitmentSlot returns the storage slot of a commitment in IBCHost, which keeps the commitments in
+// mapping(bytes32 => bytes32) at slot 0 keyed by keccak256 of their paths, following the Solidity storage layout
+func ibcHostCommitmentSlot(path string) []byte {
+	return crypto.Keccak256(crypto.Keccak256([]byte(path)), common.Hash{}.Bytes())
+}
+
+func TestNextSequenceRecvCommitmentSlot(t *testing.T) {
+	// the light client verifies the other commitments at the slots of the same layout
+	channelSlot, err := hmylctypes.ChannelCommitmentSlot("transfer", "channel-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := ibcHostCommitmentSlot("channelEnds/ports/transfer/channels/channel-0"); !bytes.Equal(channelSlot, expected) {
+		t.Fatalf("the layout of commitments doesn't match the light client: expected %x, but got %x", expected, channelSlot)
+	}
+
+	// keccak256(keccak256("nextSequenceRecv/ports/transfer/channels/channel-0") . uint256(0))
+	expected := common.FromHex("0x8294a0eaf77b80eaee1a3daa3379738dcebcda86fd566abdcc596ff3b20c0649")
+	if slot := nextSequenceRecvCommitmentSlot("transfer", "channel-0"); !bytes.Equal(slot, expected) {
+		t.Fatalf("expected %x, but got %x", expected, slot)
+	}
+}
@@ -0,0 +1,198 @@
+package harmony
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
+	"github.com/cosmos/ibc-go/modules/core/exported"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	proto "github.com/gogo/protobuf/proto"
+)
+
+const (
+	receiptPollInterval = time.Second
+	receiptTimeout      = 2 * time.Minute
+
+	receiptStatusSuccessful = 1
+)
+
+// Receipt is the receipt of a transaction
+type Receipt struct {
+	TxHash      common.Hash  `json:"transactionHash"`
+	BlockNumber uint64       `json:"blockNumber"`
+	Status      uint64       `json:"status"`
+	Logs        []ReceiptLog `json:"logs"`
+}
+
+// ReceiptLog is a log emitted by a transaction
+type ReceiptLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+// TransactionReceipt returns the receipt of the given transaction, or nil if it isn't included in a block yet
+func (c *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*Receipt, error) {
+	val, err := c.sendRPC(ctx, MethodGetTransactionReceipt, []interface{}{txHash.Hex()})
+	if err != nil {
+		return nil, err
+	}
+	if val == nil {
+		return nil, nil
+	}
+	bz, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	var receipt Receipt
+	if err := json.Unmarshal(bz, &receipt); err != nil {
+		return nil, err
+	}
+	return &receipt, nil
+}
+
+// TransactionInput returns the input data of the given transaction
+func (c *Client) TransactionInput(ctx context.Context, txHash common.Hash) ([]byte, error) {
+	val, err := c.sendRPC(ctx, MethodGetTransactionByHash, []interface{}{txHash.Hex()})
+	if err != nil {
+		return nil, err
+	}
+	if val == nil {
+		return nil, fmt.Errorf("transaction %s not found", txHash.Hex())
+	}
+	bz, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	var tx struct {
+		Input hexutil.Bytes `json:"input"`
+	}
+	if err := json.Unmarshal(bz, &tx); err != nil {
+		return nil, err
+	}
+	return tx.Input, nil
+}
+
+// waitForReceipt waits until the given transaction is included in a block, and returns its receipt
+func (c *Chain) waitForReceipt(ctx context.Context, txHash common.Hash) (*Receipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, receiptTimeout)
+	defer cancel()
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+	for {
+		receipt, err := c.client.TransactionReceipt(ctx, txHash)
+		if err != nil {
+			return nil, err
+		}
+		if receipt != nil {
+			if receipt.Status != receiptStatusSuccessful {
+				return nil, fmt.Errorf("transaction %s failed in block %d", txHash.Hex(), receipt.BlockNumber)
+			}
+			return receipt, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("transaction %s is not included in a block: %w", txHash.Hex(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// messageLog converts the logs of IBCHost in the receipt of the given msg to a log in the same format as Cosmos SDK,
+// so that the identifiers generated by the contracts can be found in the same way as on Cosmos chains.
+func (c *Chain) messageLog(index int, msg sdk.Msg, receipt *Receipt) (sdk.ABCIMessageLog, error) {
+	events := sdk.Events{
+		sdk.NewEvent(sdk.EventTypeMessage, sdk.NewAttribute(sdk.AttributeKeyAction, "/"+proto.MessageName(msg))),
+	}
+	for _, l := range receipt.Logs {
+		if l.Address != c.config.IBCHostAddress() || len(l.Topics) == 0 {
+			continue
+		}
+		var (
+			event abi.Event
+			ok    bool
+		)
+		switch l.Topics[0] {
+		case abiGeneratedClientIdentifier.ID():
+			event, ok = abiGeneratedClientIdentifier, true
+		case abiGeneratedConnectionIdentifier.ID():
+			event, ok = abiGeneratedConnectionIdentifier, true
+		case abiGeneratedChannelIdentifier.ID():
+			event, ok = abiGeneratedChannelIdentifier, true
+		}
+		if !ok {
+			continue
+		}
+		id, err := unpackIdentifier(event, l.Data)
+		if err != nil {
+			return sdk.ABCIMessageLog{}, err
+		}
+		ev, err := c.identifierEvent(msg, id)
+		if err != nil {
+			return sdk.ABCIMessageLog{}, err
+		}
+		events = append(events, ev)
+	}
+	return sdk.NewABCIMessageLog(uint32(index), "", events), nil
+}
+
+// identifierEvent returns the event which Cosmos SDK emits when the given msg generates the identifier
+func (c *Chain) identifierEvent(msg sdk.Msg, id string) (sdk.Event, error) {
+	switch msg := msg.(type) {
+	case *clienttypes.MsgCreateClient:
+		var clientState exported.ClientState
+		if err := c.codec.UnpackAny(msg.ClientState, &clientState); err != nil {
+			return sdk.Event{}, err
+		}
+		return sdk.NewEvent(clienttypes.EventTypeCreateClient,
+			sdk.NewAttribute(clienttypes.AttributeKeyClientID, id),
+			sdk.NewAttribute(clienttypes.AttributeKeyClientType, clientState.ClientType()),
+		), nil
+	case *conntypes.MsgConnectionOpenInit:
+		return sdk.NewEvent(conntypes.EventTypeConnectionOpenInit,
+			sdk.NewAttribute(conntypes.AttributeKeyConnectionID, id),
+			sdk.NewAttribute(conntypes.AttributeKeyClientID, msg.ClientId),
+		), nil
+	case *conntypes.MsgConnectionOpenTry:
+		return sdk.NewEvent(conntypes.EventTypeConnectionOpenTry,
+			sdk.NewAttribute(conntypes.AttributeKeyConnectionID, id),
+			sdk.NewAttribute(conntypes.AttributeKeyClientID, msg.ClientId),
+		), nil
+	case *chantypes.MsgChannelOpenInit:
+		return sdk.NewEvent(chantypes.EventTypeChannelOpenInit,
+			sdk.NewAttribute(chantypes.AttributeKeyChannelID, id),
+			sdk.NewAttribute(chantypes.AttributeKeyPortID, msg.PortId),
+		), nil
+	case *chantypes.MsgChannelOpenTry:
+		return sdk.NewEvent(chantypes.EventTypeChannelOpenTry,
+			sdk.NewAttribute(chantypes.AttributeKeyChannelID, id),
+			sdk.NewAttribute(chantypes.AttributeKeyPortID, msg.PortId),
+		), nil
+	default:
+		return sdk.Event{}, fmt.Errorf("unexpected identifier %q generated by %T", id, msg)
+	}
+}
+
+// unpackIdentifier returns the identifier in the data of a Generated*Identifier event
+func unpackIdentifier(event abi.Event, data []byte) (string, error) {
+	values, err := event.Inputs.UnpackValues(data)
+	if err != nil {
+		return "", err
+	}
+	if len(values) != 1 {
+		return "", fmt.Errorf("unexpected number of values in %s: %d", event.Name, len(values))
+	}
+	id, ok := values[0].(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected value in %s: %v", event.Name, values[0])
+	}
+	return id, nil
+}
@@ -0,0 +1,89 @@
+package harmony
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
+	tmclient "github.com/cosmos/ibc-go/modules/light-clients/07-tendermint/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func identifierLog(t *testing.T, address common.Address, event abi.Event, id string) ReceiptLog {
+	data, err := event.Inputs.Pack(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ReceiptLog{Address: address, Topics: []common.Hash{event.ID()}, Data: data}
+}
+
+func TestMessageLog(t *testing.T) {
+	env := newTestEnv(t, 0)
+	clientState, err := clienttypes.PackClientState(&tmclient.ClientState{ChainId: "ibc0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name      string
+		msg       sdk.Msg
+		log       ReceiptLog
+		eventType string
+		key       string
+		value     string
+	}{
+		{
+			"create client",
+			&clienttypes.MsgCreateClient{ClientState: clientState},
+			identifierLog(t, testIBCHostAddress, abiGeneratedClientIdentifier, "07-tendermint-0"),
+			clienttypes.EventTypeCreateClient, clienttypes.AttributeKeyClientID, "07-tendermint-0",
+		},
+		{
+			"connection open init",
+			&conntypes.MsgConnectionOpenInit{ClientId: "07-tendermint-0"},
+			identifierLog(t, testIBCHostAddress, abiGeneratedConnectionIdentifier, "connection-0"),
+			conntypes.EventTypeConnectionOpenInit, conntypes.AttributeKeyConnectionID, "connection-0",
+		},
+		{
+			"channel open try",
+			&chantypes.MsgChannelOpenTry{PortId: "transfer"},
+			identifierLog(t, testIBCHostAddress, abiGeneratedChannelIdentifier, "channel-1"),
+			chantypes.EventTypeChannelOpenTry, chantypes.AttributeKeyChannelID, "channel-1",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			msgLog, err := env.chain.messageLog(0, c.msg, &Receipt{Status: receiptStatusSuccessful, Logs: []ReceiptLog{c.log}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			found := false
+			for _, ev := range msgLog.Events {
+				if ev.Type != c.eventType {
+					continue
+				}
+				for _, attr := range ev.Attributes {
+					if attr.Key == c.key && attr.Value == c.value {
+						found = true
+					}
+				}
+			}
+			if !found {
+				t.Fatalf("%s.%s=%s not found in %v", c.eventType, c.key, c.value, msgLog.Events)
+			}
+		})
+	}
+
+	// logs of other contracts are ignored
+	other := identifierLog(t, common.HexToAddress("0x01"), abiGeneratedClientIdentifier, "07-tendermint-0")
+	msgLog, err := env.chain.messageLog(0, &clienttypes.MsgUpdateClient{}, &Receipt{Logs: []ReceiptLog{other}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgLog.Events) != 1 || msgLog.Events[0].Type != sdk.EventTypeMessage {
+		t.Fatalf("unexpected events: %v", msgLog.Events)
+	}
+}
@@ -0,0 +1,164 @@
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/cosmos/ibc-go/modules/core/exported"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/go-sdk/pkg/transaction"
+	harmonytypes "github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/numeric"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/ibchost"
+)
+
+const (
+	methodHostSetIBCModule      = "setIBCModule"
+	methodHostSetClientState    = "setClientState"
+	methodHostSetConsensusState = "setConsensusState"
+)
+
+// TxRecoverClient overwrites the state of an expired or frozen client with the latest state of a substitute client,
+// which keeps the connections and channels built on the subject client.
+// IBCHost only accepts state writes from its IBC module, so the relayer key must be the owner of IBCHost:
+// it takes over the IBC module temporarily and hands it back to IBCHandler afterwards.
+func (c *Chain) TxRecoverClient(subjectClientID, substituteClientID string) (err error) {
+	opts := c.CallOpts(context.Background(), -1)
+	subject, err := c.queryClientStateByID(subjectClientID)
+	if err != nil {
+		return err
+	}
+	substitute, err := c.queryClientStateByID(substituteClientID)
+	if err != nil {
+		return err
+	}
+	if subject.ClientType() != substitute.ClientType() {
+		return fmt.Errorf("client type mismatch: subject=%v substitute=%v", subject.ClientType(), substitute.ClientType())
+	}
+	latestHeight := substitute.GetLatestHeight()
+	if !subject.GetLatestHeight().LT(latestHeight) {
+		return fmt.Errorf("substitute client height %v must be greater than subject client height %v", latestHeight, subject.GetLatestHeight())
+	}
+	height := ibchost.HeightData{
+		RevisionNumber: latestHeight.GetRevisionNumber(),
+		RevisionHeight: latestHeight.GetRevisionHeight(),
+	}
+	clientStateBytes, _, err := c.ibcHost.GetClientState(opts, substituteClientID)
+	if err != nil {
+		return err
+	}
+	consensusStateBytes, found, err := c.ibcHost.GetConsensusState(opts, substituteClientID, height)
+	if err != nil {
+		return err
+	} else if !found {
+		return fmt.Errorf("consensus state of substitute client not found: %v", latestHeight)
+	}
+
+	account, err := c.getAccount()
+	if err != nil {
+		return err
+	}
+	if err := c.checkIBCHostOwner(account.Address); err != nil {
+		return err
+	}
+	ctx := context.Background()
+	tx, err := c.txIbcHost(methodHostSetIBCModule, account.Address)
+	if err != nil {
+		return err
+	}
+	// the IBC module is restored even if any step below fails, including waiting for the takeover
+	defer func() {
+		if e := c.txIbcHostAndWait(ctx, methodHostSetIBCModule, c.config.IBCHandlerAddress()); e != nil {
+			if err == nil {
+				err = fmt.Errorf("failed to restore the IBC module of IBCHost: %w", e)
+			} else {
+				err = fmt.Errorf("%v, and failed to restore the IBC module of IBCHost: %w", err, e)
+			}
+		}
+	}()
+	if _, err := c.waitForReceipt(ctx, tx.Hash()); err != nil {
+		return fmt.Errorf("failed to take over the IBC module of IBCHost: %w", err)
+	}
+	if err := c.txIbcHostAndWait(ctx, methodHostSetClientState, subjectClientID, clientStateBytes); err != nil {
+		return fmt.Errorf("failed to set the client state: %w", err)
+	}
+	if err := c.txIbcHostAndWait(ctx, methodHostSetConsensusState, subjectClientID, height, consensusStateBytes); err != nil {
+		return fmt.Errorf("failed to set the consensus state: %w", err)
+	}
+	return nil
+}
+
+// checkIBCHostOwner returns an error unless the address is the owner of IBCHost.
+// IBCHost doesn't expose its owner, so setIBCModule, which only the owner can call, is simulated with the current module.
+func (c *Chain) checkIBCHostOwner(address common.Address) error {
+	ctx := context.Background()
+	module, err := c.ibcHost.GetIBCModule(c.CallOpts(ctx, -1))
+	if err != nil {
+		return err
+	}
+	input, err := c.ibcHostAbi.Pack(methodHostSetIBCModule, module)
+	if err != nil {
+		return err
+	}
+	ethClient, err := c.client.ETHClient()
+	if err != nil {
+		return err
+	}
+	to := c.config.IBCHostAddress()
+	if _, err := ethClient.CallContract(ctx, ethereum.CallMsg{From: address, To: &to, Data: input}, nil); err != nil {
+		return fmt.Errorf("the relayer key %s must be the owner of IBCHost: %w", address.Hex(), err)
+	}
+	return nil
+}
+
+func (c *Chain) queryClientStateByID(clientID string) (exported.ClientState, error) {
+	s, found, err := c.ibcHost.GetClientState(c.CallOpts(context.Background(), -1), clientID)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, fmt.Errorf("client not found: %v", clientID)
+	}
+	var clientState exported.ClientState
+	if err := c.Codec().UnmarshalInterface(s, &clientState); err != nil {
+		return nil, err
+	}
+	return clientState, nil
+}
+
+func (c *Chain) txIbcHost(method string, params ...interface{}) (*harmonytypes.Transaction, error) {
+	input, err := c.ibcHostAbi.Pack(method, params...)
+	if err != nil {
+		log.Println("abi.Pack error")
+		return nil, err
+	}
+	account, err := c.getAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err = c.keyStore.Unlock(account, ""); err != nil {
+		return nil, err
+	}
+	messenger := c.client.messenger(context.Background())
+	controller := transaction.NewController(messenger, c.keyStore, &account, *c.chainId)
+	nonce := transaction.GetNextPendingNonce(account.Address.Hex(), messenger)
+	err = controller.ExecuteTransaction(nonce, c.config.GasLimit, &c.config.IbcHostAddress, c.config.ShardId, c.config.ShardId, numeric.NewDec(0), c.config.GasPriceDec(), input)
+	if err != nil {
+		return nil, err
+	}
+	if err = c.keyStore.Lock(account.Address); err != nil {
+		return nil, err
+	}
+	return controller.TransactionInfo(), nil
+}
+
+// txIbcHostAndWait sends a transaction to IBCHost, and waits for it to succeed
+func (c *Chain) txIbcHostAndWait(ctx context.Context, method string, params ...interface{}) error {
+	tx, err := c.txIbcHost(method, params...)
+	if err != nil {
+		return err
+	}
+	_, err = c.waitForReceipt(ctx, tx.Hash())
+	return err
+}
@@ -0,0 +1,34 @@
+package harmony
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/ibchost"
+)
+
+func TestCheckIBCHostOwner(t *testing.T) {
+	env := newTestEnv(t, 0)
+	relayer := common.HexToAddress("0x1000000000000000000000000000000000000007")
+	env.beacon.HandleCall(testIBCHostAddress, abiCallHandler(t, ibchost.IbchostABI, map[string][]interface{}{
+		"getIBCModule": {testIBCHandlerAddress},
+		"setIBCModule": {},
+	}))
+	if err := env.chain.checkIBCHostOwner(relayer); err != nil {
+		t.Fatal(err)
+	}
+
+	// setIBCModule reverts unless it is called by the owner
+	handler := abiCallHandler(t, ibchost.IbchostABI, map[string][]interface{}{"getIBCModule": {testIBCHandlerAddress}})
+	env.beacon.HandleCall(testIBCHostAddress, func(input []byte) ([]byte, error) {
+		out, err := handler(input)
+		if err != nil {
+			return nil, errors.New("execution reverted")
+		}
+		return out, nil
+	})
+	if err := env.chain.checkIBCHostOwner(relayer); err == nil {
+		t.Fatal("a key which isn't the owner must be rejected")
+	}
+}
@@ -1,6 +1,7 @@
 package harmony
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -31,43 +32,53 @@ const (
 	methodChannelOpenTry        = "channelOpenTry"
 	methodChannelOpenAck        = "channelOpenAck"
 	methodChannelOpenConfirm    = "channelOpenConfirm"
+	methodChannelCloseInit      = "channelCloseInit"
+	methodChannelCloseConfirm   = "channelCloseConfirm"
 	methodRecvPacket            = "recvPacket"
 	methodAcknowledgement       = "acknowledgePacket"
 )
 
-// SendMsgs sends msgs to the chain
+// SendMsgs sends msgs to the chain, and waits for them to be included in blocks.
+// It returns the logs of the msgs in the same format as Cosmos SDK,
+// which contain the identifiers of clients, connections and channels created by them.
 func (c *Chain) SendMsgs(msgs []sdk.Msg) ([]byte, error) {
-	for _, msg := range msgs {
+	logs := make(sdk.ABCIMessageLogs, 0, len(msgs))
+	for i, msg := range msgs {
 		var (
+			tx  *harmonytypes.Transaction
 			err error
 		)
 		switch msg := msg.(type) {
 		case *clienttypes.MsgCreateClient:
-			_, err = c.TxCreateClient(msg)
+			tx, err = c.TxCreateClient(msg)
 		case *clienttypes.MsgUpdateClient:
-			_, err = c.TxUpdateClient(msg)
+			tx, err = c.TxUpdateClient(msg)
 		case *conntypes.MsgConnectionOpenInit:
-			_, err = c.TxConnectionOpenInit(msg)
+			tx, err = c.TxConnectionOpenInit(msg)
 		case *conntypes.MsgConnectionOpenTry:
-			_, err = c.TxConnectionOpenTry(msg)
+			tx, err = c.TxConnectionOpenTry(msg)
 		case *conntypes.MsgConnectionOpenAck:
-			_, err = c.TxConnectionOpenAck(msg)
+			tx, err = c.TxConnectionOpenAck(msg)
 		case *conntypes.MsgConnectionOpenConfirm:
-			_, err = c.TxConnectionOpenConfirm(msg)
+			tx, err = c.TxConnectionOpenConfirm(msg)
 		case *chantypes.MsgChannelOpenInit:
-			_, err = c.TxChannelOpenInit(msg)
+			tx, err = c.TxChannelOpenInit(msg)
 		case *chantypes.MsgChannelOpenTry:
-			_, err = c.TxChannelOpenTry(msg)
+			tx, err = c.TxChannelOpenTry(msg)
 		case *chantypes.MsgChannelOpenAck:
-			_, err = c.TxChannelOpenAck(msg)
+			tx, err = c.TxChannelOpenAck(msg)
 		case *chantypes.MsgChannelOpenConfirm:
-			_, err = c.TxChannelOpenConfirm(msg)
+			tx, err = c.TxChannelOpenConfirm(msg)
+		case *chantypes.MsgChannelCloseInit:
+			tx, err = c.TxChannelCloseInit(msg)
+		case *chantypes.MsgChannelCloseConfirm:
+			tx, err = c.TxChannelCloseConfirm(msg)
 		case *chantypes.MsgRecvPacket:
-			_, err = c.TxRecvPacket(msg)
+			tx, err = c.TxRecvPacket(msg)
 		case *chantypes.MsgAcknowledgement:
-			_, err = c.TxAcknowledgement(msg)
+			tx, err = c.TxAcknowledgement(msg)
 		case *transfertypes.MsgTransfer:
-			_, err = c.TxMsgTransfer(msg)
+			tx, err = c.TxMsgTransfer(msg)
 
 		default:
 			panic("illegal msg type")
@@ -75,8 +86,17 @@ func (c *Chain) SendMsgs(msgs []sdk.Msg) ([]byte, error) {
 		if err != nil {
 			return nil, err
 		}
+		receipt, err := c.waitForReceipt(context.Background(), tx.Hash())
+		if err != nil {
+			return nil, err
+		}
+		msgLog, err := c.messageLog(i, msg, receipt)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, msgLog)
 	}
-	return nil, nil
+	return []byte(logs.String()), nil
 }
 
 // Send sends msgs to the chain and logging a result of it
@@ -246,6 +266,22 @@ func (c *Chain) TxChannelOpenConfirm(msg *chantypes.MsgChannelOpenConfirm) (*har
 	})
 }
 
+func (c *Chain) TxChannelCloseInit(msg *chantypes.MsgChannelCloseInit) (*harmonytypes.Transaction, error) {
+	return c.txIbcHandler(methodChannelCloseInit, ibchandler.IBCMsgsMsgChannelCloseInit{
+		PortId:    msg.PortId,
+		ChannelId: msg.ChannelId,
+	})
+}
+
+func (c *Chain) TxChannelCloseConfirm(msg *chantypes.MsgChannelCloseConfirm) (*harmonytypes.Transaction, error) {
+	return c.txIbcHandler(methodChannelCloseConfirm, ibchandler.IBCMsgsMsgChannelCloseConfirm{
+		PortId:      msg.PortId,
+		ChannelId:   msg.ChannelId,
+		ProofInit:   msg.ProofInit,
+		ProofHeight: ibchandler.HeightData(msg.ProofHeight),
+	})
+}
+
 func (c *Chain) TxRecvPacket(msg *chantypes.MsgRecvPacket) (*harmonytypes.Transaction, error) {
 	fmt.Println("*********tx recv-packet", "source", msg.Packet.SourceChannel, "Destination", msg.Packet.DestinationChannel)
 	return c.txIbcHandler(methodRecvPacket, ibchandler.IBCMsgsMsgPacketRecv{
@@ -295,8 +331,9 @@ func (c *Chain) txIbcHandler(method string, params ...interface{}) (*harmonytype
 	if err = c.keyStore.Unlock(account, ""); err != nil {
 		return nil, err
 	}
-	controller := transaction.NewController(c.client.messenger, c.keyStore, &account, *c.chainId)
-	nonce := transaction.GetNextPendingNonce(account.Address.Hex(), c.client.messenger)
+	messenger := c.client.messenger(context.Background())
+	controller := transaction.NewController(messenger, c.keyStore, &account, *c.chainId)
+	nonce := transaction.GetNextPendingNonce(account.Address.Hex(), messenger)
 	err = controller.ExecuteTransaction(nonce, c.config.GasLimit, &c.config.IbcHandlerAddress, c.config.ShardId, c.config.ShardId, numeric.NewDec(0), c.config.GasPriceDec(), input)
 	if err != nil {
 		log.Println("config.GasLimit", c.config.GasLimit)
@@ -306,7 +343,7 @@ func (c *Chain) txIbcHandler(method string, params ...interface{}) (*harmonytype
 	txhashlen := len(*txhash)
 	fmt.Println("--------send recvPacket ---------", "to address", c.config.IbcHandlerAddress, "txhash", controller.TransactionHash(), txhashlen, controller.TransactionInfo().Hash().Hex())
 	if err = c.keyStore.Lock(account.Address); err != nil {
-		panic(err)
+		return nil, err
 	}
 	return controller.TransactionInfo(), nil
 }
@@ -324,15 +361,16 @@ func (c *Chain) tx(to string, abi *abi.ABI, method string, params ...interface{}
 	if err = c.keyStore.Unlock(account, ""); err != nil {
 		return nil, err
 	}
-	controller := transaction.NewController(c.client.messenger, c.keyStore, &account, *c.chainId)
+	messenger := c.client.messenger(context.Background())
+	controller := transaction.NewController(messenger, c.keyStore, &account, *c.chainId)
 	// XXX or pending nonce
-	nonce := transaction.GetNextNonce(account.Address.Hex(), c.client.messenger)
+	nonce := transaction.GetNextNonce(account.Address.Hex(), messenger)
 	err = controller.ExecuteTransaction(nonce, c.config.GasLimit, &to, c.config.ShardId, c.config.ShardId, numeric.NewDec(0), c.config.GasPriceDec(), input)
 	if err != nil {
 		return nil, err
 	}
 	if err = c.keyStore.Lock(account.Address); err != nil {
-		panic(err)
+		return nil, err
 	}
 	return controller.TransactionInfo(), nil
 }
@@ -1,6 +1,8 @@
 package harmony
 
 import (
+	"strings"
+
 	connectiontypes "github.com/cosmos/ibc-go/modules/core/03-connection/types"
 	channeltypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
 	commitmenttypes "github.com/cosmos/ibc-go/modules/core/23-commitment/types"
@@ -35,3 +37,16 @@ func channelToPB(chann ibchost.ChannelData) channeltypes.Channel {
 		Version:        chann.Version,
 	}
 }
+
+// orderFromString returns the channel ordering of the given order of a path end,
+// which is either "ordered" or "unordered"
+func orderFromString(order string) channeltypes.Order {
+	switch strings.ToLower(order) {
+	case "ordered":
+		return channeltypes.ORDERED
+	case "unordered":
+		return channeltypes.UNORDERED
+	default:
+		return channeltypes.NONE
+	}
+}
@@ -0,0 +1,141 @@
+package harmony
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// operatorRole is the role of ICS20Bank granted by setOperator
+var operatorRole = crypto.Keccak256Hash([]byte("OPERATOR_ROLE"))
+
+// ConfigCheck is the result of checking the chain config against the chain
+type ConfigCheck struct {
+	Name string
+	// Err is nil if the check passed
+	Err error
+}
+
+// ValidateConfig checks that the configured contracts exist and are wired up,
+// that the chain ID matches the node, and that the relayer key can pay for a transaction.
+// Every check runs even if some of them fail.
+func (c *Chain) ValidateConfig(ctx context.Context) []ConfigCheck {
+	var checks []ConfigCheck
+	check := func(name string, fn func() error) {
+		checks = append(checks, ConfigCheck{Name: name, Err: fn()})
+	}
+	ethClient, err := c.client.ETHClient()
+	if err != nil {
+		return []ConfigCheck{{Name: "rpc", Err: err}}
+	}
+	opts := c.CallOpts(ctx, -1)
+
+	check("chain id", func() error {
+		expected, err := c.config.ChainID()
+		if err != nil {
+			return err
+		}
+		actual, err := c.client.ChainID(ctx)
+		if err != nil {
+			return err
+		}
+		if expected.Value.Uint64() != actual {
+			return fmt.Errorf("harmony_chain_id %s is %v, but the node is of %d", c.config.HarmonyChainId, expected.Value, actual)
+		}
+		return nil
+	})
+	for _, a := range []struct {
+		name    string
+		address string
+	}{
+		{"ibc_host_address", c.config.IbcHostAddress},
+		{"ibc_handler_address", c.config.IbcHandlerAddress},
+		{"ics20_bank_address", c.config.Ics20BankAddress},
+		{"ics20_transfer_bank_address", c.config.Ics20TransferBankAddress},
+		{"token_address", c.config.TokenAddress},
+	} {
+		a := a
+		check(a.name, func() error {
+			return checkCode(ctx, ethClient, a.address)
+		})
+	}
+	check("ibc module", func() error {
+		module, err := c.ibcHost.GetIBCModule(opts)
+		if err != nil {
+			return err
+		}
+		if module != c.config.IBCHandlerAddress() {
+			return fmt.Errorf("the IBC module of IBCHost is %s, not ibc_handler_address", module.Hex())
+		}
+		return nil
+	})
+	check("transfer port", func() error {
+		owner, found, err := c.ibcHost.GetModuleOwner(opts, []byte(PortTransfer))
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("port %s is not bound", PortTransfer)
+		}
+		if owner != c.config.ICS20TransferBankAddress() {
+			return fmt.Errorf("port %s is bound to %s, not ics20_transfer_bank_address", PortTransfer, owner.Hex())
+		}
+		return nil
+	})
+	check("client type", func() error {
+		impl, found, err := c.ibcHost.GetClientImpl(opts, TendermintLightClientType)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("client type %s is not registered", TendermintLightClientType)
+		}
+		return checkCode(ctx, ethClient, impl.Hex())
+	})
+	check("ics20 bank operator", func() error {
+		ok, err := c.ics20Bank.HasRole(opts, operatorRole, c.config.ICS20TransferBankAddress())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("ics20_transfer_bank_address is not an operator of ICS20Bank")
+		}
+		return nil
+	})
+	check("relayer balance", func() error {
+		key, err := crypto.HexToECDSA(c.config.ShardPrivateKey)
+		if err != nil {
+			return fmt.Errorf("invalid shard_private_key: %w", err)
+		}
+		address := crypto.PubkeyToAddress(key.PublicKey)
+		balance, err := ethClient.BalanceAt(ctx, address, nil)
+		if err != nil {
+			return err
+		}
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(c.config.GasLimit), c.config.GasPriceWei())
+		if balance.Sign() == 0 || balance.Cmp(fee) < 0 {
+			return fmt.Errorf("the balance of %s is %v, which is less than gas_limit * gas_price = %v", address.Hex(), balance, fee)
+		}
+		return nil
+	})
+	return checks
+}
+
+func checkCode(ctx context.Context, ethClient *ethclient.Client, address string) error {
+	if !common.IsHexAddress(address) {
+		return fmt.Errorf("invalid address %q", address)
+	}
+	code, err := ethClient.CodeAt(ctx, common.HexToAddress(address), nil)
+	if err != nil {
+		return err
+	}
+	if len(code) == 0 {
+		return fmt.Errorf("no contract code at %s", address)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package harmony
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/ibchost"
+	"github.com/hyperledger-labs/yui-ibc-solidity/pkg/contract/ics20bank"
+)
+
+var testTendermintClientAddress = common.HexToAddress("0x1000000000000000000000000000000000000006")
+
+func abiCallHandler(t *testing.T, abiJSON string, outputs map[string][]interface{}) func(input []byte) ([]byte, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return func(input []byte) ([]byte, error) {
+		method, err := parsed.MethodById(input[:4])
+		if err != nil {
+			return nil, err
+		}
+		out, ok := outputs[method.Name]
+		if !ok {
+			return nil, fmt.Errorf("unexpected call: %v", method.Name)
+		}
+		return method.Outputs.Pack(out...)
+	}
+}
+
+func failedChecks(checks []ConfigCheck) map[string]bool {
+	failed := make(map[string]bool)
+	for _, c := range checks {
+		if c.Err != nil {
+			failed[c.Name] = true
+		}
+	}
+	return failed
+}
+
+func TestValidateConfig(t *testing.T) {
+	env := newTestEnv(t, 0)
+	env.beacon.HandleCall(testIBCHostAddress, abiCallHandler(t, ibchost.IbchostABI, map[string][]interface{}{
+		"getIBCModule":   {testIBCHandlerAddress},
+		"getModuleOwner": {testTransferAddress, true},
+		"getClientImpl":  {testTendermintClientAddress, true},
+	}))
+	noop := func(input []byte) ([]byte, error) { return nil, nil }
+	for _, addr := range []common.Address{testIBCHandlerAddress, testTransferAddress, testTokenAddress, testTendermintClientAddress} {
+		env.beacon.HandleCall(addr, noop)
+	}
+	env.beacon.HandleCall(testICS20BankAddress, abiCallHandler(t, ics20bank.Ics20bankABI, map[string][]interface{}{"hasRole": {true}}))
+	key, err := crypto.HexToECDSA(env.chain.config.ShardPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env.beacon.SetBalance(crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1e18))
+
+	checks := env.chain.ValidateConfig(context.Background())
+	if failed := failedChecks(checks); len(failed) != 0 {
+		t.Fatalf("unexpected failures: %v", checks)
+	}
+
+	// misconfigurations are reported by the checks
+	env.beacon.HandleCall(testICS20BankAddress, abiCallHandler(t, ics20bank.Ics20bankABI, map[string][]interface{}{"hasRole": {false}}))
+	env.beacon.SetBalance(crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1))
+	env.beacon.SetChainID(1)
+	env.chain.config.TokenAddress = common.HexToAddress("0x2000000000000000000000000000000000000000").Hex()
+	failed := failedChecks(env.chain.ValidateConfig(context.Background()))
+	for _, name := range []string{"chain id", "token_address", "ics20 bank operator", "relayer balance"} {
+		if !failed[name] {
+			t.Errorf("check %q must fail", name)
+		}
+	}
+	if len(failed) != 4 {
+		t.Errorf("unexpected failures: %v", failed)
+	}
+}
@@ -38,7 +38,9 @@ var (
 
 // Chain represents the necessary data for connecting to and indentifying a chain and its counterparites
 type Chain struct {
-	config ChainConfig
+	// config is the config whose references are resolved, and rawConfig is the one as written
+	config    ChainConfig
+	rawConfig ChainConfig
 
 	// TODO: make these private
 	HomePath string           `yaml:"-" json:"-"`
@@ -63,8 +65,9 @@ func (c *Chain) ChainID() string {
 	return c.config.ChainId
 }
 
+// Config returns the config of the chain as written, whose references are left unresolved
 func (c *Chain) Config() ChainConfig {
-	return c.config
+	return c.rawConfig
 }
 
 func (c *Chain) ClientID() string {
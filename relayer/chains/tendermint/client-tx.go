@@ -9,43 +9,62 @@ import (
 	tmclient "github.com/cosmos/ibc-go/modules/light-clients/07-tendermint/types"
 	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/tendermint/types"
 	abci "github.com/tendermint/tendermint/abci/types"
-	"github.com/tendermint/tendermint/light"
 )
 
+const defaultMaxClockDrift = time.Minute * 10
+
+// defaultTrustingPeriod is 2/3 of the default unbonding period of 21 days
+const defaultTrustingPeriod = time.Hour * 336
+
 func createClient(
 	dstHeader *types.TmHeader,
-	trustingPeriod, unbondingPeriod time.Duration,
+	config ProverConfig,
+	unbondingPeriod time.Duration,
 	consensusParams *abci.ConsensusParams,
-	signer sdk.AccAddress) *clienttypes.MsgCreateClient {
+	signer sdk.AccAddress) (*clienttypes.MsgCreateClient, error) {
 	if err := dstHeader.ValidateBasic(); err != nil {
-		panic(err)
+		return nil, err
+	}
+	trustLevel, err := config.TrustLevelFraction()
+	if err != nil {
+		return nil, err
+	}
+	trustingPeriod, err := config.TrustingPeriodDuration()
+	if err != nil {
+		return nil, err
+	}
+	maxClockDrift, err := config.MaxClockDriftDuration()
+	if err != nil {
+		return nil, err
 	}
 
 	// Blank Client State
 	clientState := tmclient.NewClientState(
 		dstHeader.SignedHeader.Header.ChainId,
-		tmclient.Fraction(light.DefaultTrustLevel),
+		trustLevel,
 		trustingPeriod,
 		unbondingPeriod,
-		time.Minute*10,
+		maxClockDrift,
 		dstHeader.GetHeight().(clienttypes.Height),
 		commitmenttypes.GetSDKSpecs(),
-		[]string{"upgrade", "upgradedIBCState"},
-		false,
-		false,
+		config.UpgradePathOrDefault(),
+		config.AllowUpdateAfterExpiry,
+		config.AllowUpdateAfterMisbehaviour,
 	)
+	if err := clientState.Validate(); err != nil {
+		return nil, err
+	}
 
 	msg, err := clienttypes.NewMsgCreateClient(
 		clientState,
 		dstHeader.ConsensusState(),
 		signer.String(),
 	)
-
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	if err = msg.ValidateBasic(); err != nil {
-		panic(err)
+		return nil, err
 	}
-	return msg
+	return msg, nil
 }
@@ -17,6 +17,8 @@ func TendermintCmd(m codec.Codec, ctx *config.Context) *cobra.Command {
 		keysCmd(ctx),
 		lightCmd(ctx),
 		xfersend(ctx),
+		updateClientCmd(ctx),
+		clientUpdateProposalCmd(ctx),
 	)
 
 	return cmd
@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/tendermint"
@@ -10,6 +16,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	// defaultTrustingPeriod is used when the unbonding period isn't discovered from the node
+	defaultTrustingPeriod = "336h"
+	defaultGasPrices      = "0.025stake"
+	discoveryTimeout      = 10 * time.Second
+)
+
 func configCmd(m codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "config",
@@ -25,21 +38,114 @@ func configCmd(m codec.Codec) *cobra.Command {
 
 func generateChainConfigCmd(m codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  "generate",
-		Args: cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) (err error) {
-			// TODO make it configurable
-			c := tendermint.ChainConfig{
-				Key:           "testkey",
-				ChainId:       args[0],
-				RpcAddr:       "http://localhost:26557",
-				AccountPrefix: "cosmos",
-				GasAdjustment: 1.5,
-				GasPrices:     "0.025stake",
-			}
-			p := tendermint.ProverConfig{
-				TrustingPeriod: "336h",
+		Use:   "generate [chain-id]",
+		Short: "generate the config of a Tendermint chain",
+		Long: "Generate the config of a Tendermint chain. Given the chain ID, the config is generated without contacting the node." +
+			" Without it, or with --discover, the chain ID is read from /status of the node and the trusting period and the gas prices" +
+			" are derived from its staking params: the trusting period is 2/3 of the unbonding period, and the gas prices are 0.025 of" +
+			" the bond denom, as the minimum gas prices of a node can't be queried." +
+			" Flags given explicitly take precedence over discovered values. With --interactive, each value is prompted with its default",
+		Args: cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			var (
+				c   tendermint.ChainConfig
+				p   tendermint.ProverConfig
+				err error
+			)
+			if len(args) == 1 {
+				c.ChainId = args[0]
+			}
+			if c.Key, err = flags.GetString(flagKey); err != nil {
+				return err
+			}
+			if c.RpcAddr, err = flags.GetString(flagRPCAddr); err != nil {
+				return err
+			}
+			if c.AccountPrefix, err = flags.GetString(flagAccountPrefix); err != nil {
+				return err
+			}
+			if c.GasAdjustment, err = flags.GetFloat64(flagGasAdjustment); err != nil {
+				return err
+			}
+			if c.GasPrices, err = flags.GetString(flagGasPrices); err != nil {
+				return err
+			}
+			if p.TrustingPeriod, err = flags.GetString(flagTrustingPeriod); err != nil {
+				return err
+			}
+			if p.TrustLevel, err = flags.GetString(flagTrustLevel); err != nil {
+				return err
+			}
+			if p.MaxClockDrift, err = flags.GetString(flagMaxClockDrift); err != nil {
+				return err
+			}
+			if p.UpgradePath, err = flags.GetStringSlice(flagUpgradePath); err != nil {
+				return err
 			}
+			if p.AllowUpdateAfterExpiry, err = flags.GetBool(flagAllowUpdateAfterExpiry); err != nil {
+				return err
+			}
+			if p.AllowUpdateAfterMisbehaviour, err = flags.GetBool(flagAllowUpdateAfterMisbehaviour); err != nil {
+				return err
+			}
+			discover, err := flags.GetBool(flagDiscover)
+			if err != nil {
+				return err
+			}
+			// the chain id argument keeps generating the config offline
+			discover = discover || len(args) == 0
+			interactive, err := flags.GetBool(flagInteractive)
+			if err != nil {
+				return err
+			}
+
+			in := newPrompter(cmd.InOrStdin(), cmd.ErrOrStderr(), interactive)
+			if discover {
+				c.RpcAddr = in.ask("rpc address", c.RpcAddr)
+				params, err := tendermint.DiscoverNodeParams(context.Background(), c.RpcAddr, discoveryTimeout)
+				if err != nil {
+					return fmt.Errorf("failed to discover the node at %s (give the chain-id argument to skip it): %w", c.RpcAddr, err)
+				}
+				if c.ChainId != "" && c.ChainId != params.ChainID {
+					return fmt.Errorf("chain-id %s doesn't match %s of the node", c.ChainId, params.ChainID)
+				}
+				c.ChainId = params.ChainID
+				if !flags.Changed(flagTrustingPeriod) {
+					p.TrustingPeriod = params.TrustingPeriod().String()
+				}
+				if !flags.Changed(flagGasPrices) {
+					c.GasPrices = params.GasPrices()
+				}
+			}
+			if p.TrustingPeriod == "" {
+				p.TrustingPeriod = defaultTrustingPeriod
+			}
+			if c.GasPrices == "" {
+				c.GasPrices = defaultGasPrices
+			}
+
+			if !discover {
+				c.ChainId = in.ask("chain id", c.ChainId)
+				c.RpcAddr = in.ask("rpc address", c.RpcAddr)
+			}
+			c.Key = in.ask("key", c.Key)
+			c.AccountPrefix = in.ask("account prefix", c.AccountPrefix)
+			gasAdjustment := in.ask("gas adjustment", strconv.FormatFloat(c.GasAdjustment, 'f', -1, 64))
+			if c.GasAdjustment, err = strconv.ParseFloat(gasAdjustment, 64); err != nil {
+				return fmt.Errorf("invalid gas adjustment: %w", err)
+			}
+			c.GasPrices = in.ask("gas prices", c.GasPrices)
+			p.TrustingPeriod = in.ask("trusting period", p.TrustingPeriod)
+			p.TrustLevel = in.ask("trust level", p.TrustLevel)
+			p.MaxClockDrift = in.ask("max clock drift", p.MaxClockDrift)
+			if err := in.err; err != nil {
+				return err
+			}
+			if err := p.Validate(); err != nil {
+				return err
+			}
+
 			config, err := core.NewChainProverConfig(m, &c, &p)
 			if err != nil {
 				return err
@@ -48,9 +154,52 @@ func generateChainConfigCmd(m codec.Codec) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			fmt.Println(string(bz))
+			fmt.Fprintln(cmd.OutOrStdout(), string(bz))
 			return nil
 		},
 	}
+	cmd.Flags().String(flagKey, "testkey", "name of the relayer key in the keyring")
+	cmd.Flags().String(flagRPCAddr, "http://localhost:26557", "RPC endpoint of the node")
+	cmd.Flags().String(flagAccountPrefix, "cosmos", "bech32 prefix of account addresses")
+	cmd.Flags().Float64(flagGasAdjustment, 1.5, "multiplier of the simulated gas")
+	cmd.Flags().String(flagGasPrices, "", fmt.Sprintf("gas prices (defaults to 0.025 of the bond denom of the node, or %s offline)", defaultGasPrices))
+	cmd.Flags().String(flagTrustingPeriod, "", fmt.Sprintf("trusting period of the client on the counterparty (defaults to 2/3 of the unbonding period of the node, or %s offline)", defaultTrustingPeriod))
+	cmd.Flags().String(flagTrustLevel, "", "fraction of the validator set that must sign a header (defaults to 1/3)")
+	cmd.Flags().String(flagMaxClockDrift, "", "max clock drift of the client (defaults to 10m)")
+	cmd.Flags().StringSlice(flagUpgradePath, nil, "upgrade path of the client (defaults to upgrade,upgradedIBCState)")
+	cmd.Flags().Bool(flagAllowUpdateAfterExpiry, false, "allow the client to be recovered after expiry")
+	cmd.Flags().Bool(flagAllowUpdateAfterMisbehaviour, false, "allow the client to be recovered after misbehaviour")
+	cmd.Flags().Bool(flagDiscover, false, "query the node even if the chain-id argument is given, and check that it matches")
+	cmd.Flags().BoolP(flagInteractive, "i", false, "prompt for each value")
 	return cmd
 }
+
+// prompter asks for values on the terminal, and returns the defaults if it isn't interactive
+type prompter struct {
+	r           *bufio.Reader
+	w           io.Writer
+	interactive bool
+	// err is the first error on reading the input, after which the defaults are returned
+	err error
+}
+
+func newPrompter(r io.Reader, w io.Writer, interactive bool) *prompter {
+	return &prompter{r: bufio.NewReader(r), w: w, interactive: interactive}
+}
+
+// ask returns the value entered, or the default if the input is empty
+func (p *prompter) ask(label, def string) string {
+	if !p.interactive || p.err != nil {
+		return def
+	}
+	fmt.Fprintf(p.w, "%s [%s]: ", label, def)
+	line, err := p.r.ReadString('\n')
+	if err != nil && !(err == io.EOF && line != "") {
+		p.err = fmt.Errorf("failed to read %s: %w", label, err)
+		return def
+	}
+	if v := strings.TrimSpace(line); v != "" {
+		return v
+	}
+	return def
+}
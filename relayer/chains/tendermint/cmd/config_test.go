@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/tendermint"
+)
+
+func TestPrompterAsk(t *testing.T) {
+	var out bytes.Buffer
+	in := newPrompter(strings.NewReader("ibc1\n\n  cosmos  \nlast"), &out, true)
+	for _, c := range []struct {
+		label, def, want string
+	}{
+		{"chain id", "ibc0", "ibc1"},
+		// an empty line keeps the default
+		{"key", "testkey", "testkey"},
+		{"account prefix", "", "cosmos"},
+		// the last line may lack a newline
+		{"gas prices", "0.025stake", "last"},
+		// the defaults are returned after the end of the input
+		{"trust level", "1/3", "1/3"},
+		{"max clock drift", "10m", "10m"},
+	} {
+		if v := in.ask(c.label, c.def); v != c.want {
+			t.Errorf("%s: unexpected value: %q, want %q", c.label, v, c.want)
+		}
+	}
+	if in.err == nil || !strings.Contains(in.err.Error(), "trust level") {
+		t.Errorf("unexpected error: %v", in.err)
+	}
+	// nothing is prompted after the error
+	if !strings.HasPrefix(out.String(), "chain id [ibc0]: key [testkey]: ") || strings.Contains(out.String(), "max clock drift") {
+		t.Errorf("unexpected prompts: %q", out.String())
+	}
+
+	out.Reset()
+	in = newPrompter(strings.NewReader("ibc1\n"), &out, false)
+	if v := in.ask("chain id", "ibc0"); v != "ibc0" || out.Len() != 0 || in.err != nil {
+		t.Errorf("a non-interactive prompter must return the default: %q, %q, %v", v, out.String(), in.err)
+	}
+}
+
+func TestGenerateChainConfigOffline(t *testing.T) {
+	registry := codectypes.NewInterfaceRegistry()
+	tendermint.RegisterInterfaces(registry)
+	m := codec.NewProtoCodec(registry)
+
+	// the chain id argument doesn't need the node, which is unreachable here
+	var out bytes.Buffer
+	cmd := generateChainConfigCmd(m)
+	cmd.SetArgs([]string{"ibc0", "--rpc-addr", "http://127.0.0.1:1"})
+	cmd.SetOut(&out)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{`"chain_id":"ibc0"`, `"gas_prices":"` + defaultGasPrices + `"`, `"trusting_period":"` + defaultTrustingPeriod + `"`} {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("%s is not found in %s", s, out.String())
+		}
+	}
+
+	for _, args := range [][]string{
+		{"--rpc-addr", "http://127.0.0.1:1"},
+		{"ibc0", "--discover", "--rpc-addr", "http://127.0.0.1:1"},
+	} {
+		cmd := generateChainConfigCmd(m)
+		cmd.SetArgs(args)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "failed to discover the node") {
+			t.Errorf("%v: unexpected error: %v", args, err)
+		}
+	}
+}
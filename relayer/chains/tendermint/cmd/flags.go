@@ -13,6 +13,23 @@ const (
 	flagForce               = "force"
 	flagTimeoutHeightOffset = "timeout-height-offset"
 	flagTimeoutTimeOffset   = "timeout-time-offset"
+	flagTitle               = "title"
+	flagDescription         = "description"
+	flagDeposit             = "deposit"
+
+	flagKey                          = "key"
+	flagRPCAddr                      = "rpc-addr"
+	flagAccountPrefix                = "account-prefix"
+	flagGasAdjustment                = "gas-adjustment"
+	flagGasPrices                    = "gas-prices"
+	flagTrustingPeriod               = "trusting-period"
+	flagTrustLevel                   = "trust-level"
+	flagMaxClockDrift                = "max-clock-drift"
+	flagUpgradePath                  = "upgrade-path"
+	flagAllowUpdateAfterExpiry       = "allow-update-after-expiry"
+	flagAllowUpdateAfterMisbehaviour = "allow-update-after-misbehaviour"
+	flagDiscover                     = "discover"
+	flagInteractive                  = "interactive"
 )
 
 func lightFlags(cmd *cobra.Command) *cobra.Command {
@@ -46,3 +63,10 @@ func timeoutFlags(cmd *cobra.Command) *cobra.Command {
 	}
 	return cmd
 }
+
+func proposalFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().String(flagTitle, "recover client", "title of the proposal")
+	cmd.Flags().String(flagDescription, "recover an expired or frozen client with a substitute client", "description of the proposal")
+	cmd.Flags().String(flagDeposit, "", "deposit of the proposal")
+	return cmd
+}
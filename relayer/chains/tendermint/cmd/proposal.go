@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/tendermint"
+	"github.com/mapdev33/yui-relayer/config"
+	"github.com/spf13/cobra"
+)
+
+// clientUpdateProposalCmd submits a governance proposal which replaces the state of
+// an expired or frozen client with the state of a substitute client
+func clientUpdateProposalCmd(ctx *config.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "client-update-proposal [chain-id] [subject-client-id] [substitute-client-id]",
+		Short: "submit a governance proposal to recover an expired or frozen client",
+		Long: "Submit a client update proposal to recover an expired or frozen client with a substitute client." +
+			" The client is recovered once the proposal passes",
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := ctx.Config.GetChain(args[0])
+			if err != nil {
+				return err
+			}
+			chain, ok := c.ChainI.(*tendermint.Chain)
+			if !ok {
+				return errors.New("invalid chain-id")
+			}
+			title, err := cmd.Flags().GetString(flagTitle)
+			if err != nil {
+				return err
+			}
+			description, err := cmd.Flags().GetString(flagDescription)
+			if err != nil {
+				return err
+			}
+			depositStr, err := cmd.Flags().GetString(flagDeposit)
+			if err != nil {
+				return err
+			}
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return err
+			}
+			proposer, err := chain.GetAddress()
+			if err != nil {
+				return err
+			}
+
+			content := &clienttypes.ClientUpdateProposal{
+				Title:              title,
+				Description:        description,
+				SubjectClientId:    args[1],
+				SubstituteClientId: args[2],
+			}
+			if err := content.ValidateBasic(); err != nil {
+				return err
+			}
+			msg, err := govtypes.NewMsgSubmitProposal(content, deposit, proposer)
+			if err != nil {
+				return err
+			}
+			logs, err := chain.SendMsgs([]sdk.Msg{msg})
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(logs))
+			return nil
+		},
+	}
+	return proposalFlags(cmd)
+}
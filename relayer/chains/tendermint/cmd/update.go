@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/tendermint"
+	"github.com/mapdev33/yui-relayer/config"
+	"github.com/mapdev33/yui-relayer/core"
+	"github.com/spf13/cobra"
+)
+
+// updateClientCmd sends every header of the bisection trace to the counterparty client,
+// which is needed when the validator set has changed too much since its latest height
+func updateClientCmd(ctx *config.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-client [path-name] [chain-id]",
+		Short: "update the client of a tendermint chain on the counterparty chain",
+		Long: "Update the client of a tendermint chain on the counterparty chain up to the latest height." +
+			" Intermediate headers are submitted as well if the client can't skip to the latest height at once",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, src, dst, err := ctx.Config.ChainsFromPath(args[0])
+			if err != nil {
+				return err
+			}
+			switch args[1] {
+			case src:
+			case dst:
+				src, dst = dst, src
+			default:
+				return fmt.Errorf("not found chain '%v' in the path", args[1])
+			}
+
+			prover, ok := c[src].ProverI.(*tendermint.Prover)
+			if !ok {
+				return fmt.Errorf("chain '%v' is not a tendermint chain", src)
+			}
+			header, _, _, err := prover.UpdateLightWithHeader()
+			if err != nil {
+				return err
+			}
+			headers, err := prover.SetupHeadersForUpdate(c[dst], header)
+			if err != nil {
+				return err
+			}
+			signer, err := c[dst].GetAddress()
+			if err != nil {
+				return err
+			}
+
+			msgs := make([]sdk.Msg, len(headers))
+			for i, h := range headers {
+				msgs[i] = c[dst].Path().UpdateClient(h, signer)
+			}
+			tx := core.RelayMsgs{
+				Src: []sdk.Msg{},
+				Dst: msgs,
+			}
+			if tx.Send(c[src], c[dst]); !tx.Succeeded {
+				return fmt.Errorf("failed to update the client on %s", dst)
+			}
+			fmt.Printf("updated the client on %s with %d headers\n", dst, len(headers))
+			return nil
+		},
+	}
+	return cmd
+}
@@ -2,6 +2,7 @@ package tendermint
 
 import (
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/tendermint/types"
 	"github.com/mapdev33/yui-relayer/core"
 )
@@ -18,4 +19,6 @@ func RegisterInterfaces(registry codectypes.InterfaceRegistry) {
 		&ProverConfig{},
 	)
 	types.RegisterInterfaces(registry)
+	// for client update proposals
+	govtypes.RegisterInterfaces(registry)
 }
@@ -2,24 +2,125 @@ package tendermint
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	tmclient "github.com/cosmos/ibc-go/modules/light-clients/07-tendermint/types"
+	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/configref"
 	"github.com/mapdev33/yui-relayer/core"
+	"github.com/tendermint/tendermint/light"
 )
 
 var _ core.ChainConfigI = (*ChainConfig)(nil)
 
 func (c ChainConfig) Build() (core.ChainI, error) {
+	resolved, err := c.Resolve()
+	if err != nil {
+		return nil, err
+	}
 	return &Chain{
-		config: c,
+		config:    resolved,
+		rawConfig: c,
 	}, nil
 }
 
+// Resolve returns a copy of the config whose "${ENV}" and "file://" references are resolved.
+// The references are allowed in the key, the RPC endpoint and the gas settings.
+func (c ChainConfig) Resolve() (ChainConfig, error) {
+	if err := configref.ResolveFields(
+		configref.Field{Name: "key", Value: &c.Key},
+		configref.Field{Name: "rpc_addr", Value: &c.RpcAddr},
+		configref.Field{Name: "gas_prices", Value: &c.GasPrices},
+	); err != nil {
+		return ChainConfig{}, err
+	}
+	if c.GasAdjustmentRef != "" {
+		gasAdjustment, err := configref.ResolveFloat64("gas_adjustment_ref", c.GasAdjustmentRef)
+		if err != nil {
+			return ChainConfig{}, err
+		}
+		c.GasAdjustment, c.GasAdjustmentRef = gasAdjustment, ""
+	}
+	return c, nil
+}
+
 var _ core.ProverConfigI = (*ProverConfig)(nil)
 
+// defaultUpgradePath is the upgrade path of a client created with an empty upgrade_path
+var defaultUpgradePath = []string{"upgrade", "upgradedIBCState"}
+
 func (c ProverConfig) Build(chain core.ChainI) (core.ProverI, error) {
 	chain_, ok := chain.(*Chain)
 	if !ok {
 		return nil, fmt.Errorf("chain type must be %T, not %T", &Chain{}, chain)
 	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
 	return NewProver(chain_, c), nil
 }
+
+// Validate returns an error if any parameter of the config can't be parsed
+func (c ProverConfig) Validate() error {
+	if _, err := c.TrustingPeriodDuration(); err != nil {
+		return fmt.Errorf("invalid trusting_period: %w", err)
+	}
+	if _, err := c.TrustLevelFraction(); err != nil {
+		return fmt.Errorf("invalid trust_level: %w", err)
+	}
+	if _, err := c.MaxClockDriftDuration(); err != nil {
+		return fmt.Errorf("invalid max_clock_drift: %w", err)
+	}
+	return nil
+}
+
+func (c ProverConfig) TrustingPeriodDuration() (time.Duration, error) {
+	if c.TrustingPeriod == "" {
+		return defaultTrustingPeriod, nil
+	}
+	return time.ParseDuration(c.TrustingPeriod)
+}
+
+// TrustLevelFraction parses trust_level in the form of "numerator/denominator",
+// which must be between 1/3 and 1
+func (c ProverConfig) TrustLevelFraction() (tmclient.Fraction, error) {
+	if c.TrustLevel == "" {
+		return tmclient.Fraction(light.DefaultTrustLevel), nil
+	}
+	parts := strings.Split(c.TrustLevel, "/")
+	if len(parts) != 2 {
+		return tmclient.Fraction{}, fmt.Errorf("%q is not in the form of \"numerator/denominator\"", c.TrustLevel)
+	}
+	numerator, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return tmclient.Fraction{}, fmt.Errorf("invalid numerator: %w", err)
+	}
+	denominator, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return tmclient.Fraction{}, fmt.Errorf("invalid denominator: %w", err)
+	}
+	if denominator == 0 {
+		return tmclient.Fraction{}, fmt.Errorf("denominator of %q is zero", c.TrustLevel)
+	}
+	f := tmclient.Fraction{Numerator: numerator, Denominator: denominator}
+	// ValidateTrustLevel rejects values outside [1/3, 1]
+	if err := light.ValidateTrustLevel(f.ToTendermint()); err != nil {
+		return tmclient.Fraction{}, err
+	}
+	return f, nil
+}
+
+func (c ProverConfig) MaxClockDriftDuration() (time.Duration, error) {
+	if c.MaxClockDrift == "" {
+		return defaultMaxClockDrift, nil
+	}
+	return time.ParseDuration(c.MaxClockDrift)
+}
+
+func (c ProverConfig) UpgradePathOrDefault() []string {
+	if len(c.UpgradePath) == 0 {
+		return defaultUpgradePath
+	}
+	return c.UpgradePath
+}
@@ -31,6 +31,8 @@ type ChainConfig struct {
 	AccountPrefix string  `protobuf:"bytes,4,opt,name=account_prefix,json=accountPrefix,proto3" json:"account_prefix,omitempty"`
 	GasAdjustment float64 `protobuf:"fixed64,5,opt,name=gas_adjustment,json=gasAdjustment,proto3" json:"gas_adjustment,omitempty"`
 	GasPrices     string  `protobuf:"bytes,6,opt,name=gas_prices,json=gasPrices,proto3" json:"gas_prices,omitempty"`
+	// a "${ENV}" or "file://" reference to gas_adjustment, which overrides gas_adjustment if set
+	GasAdjustmentRef string `protobuf:"bytes,7,opt,name=gas_adjustment_ref,json=gasAdjustmentRef,proto3" json:"gas_adjustment_ref,omitempty"`
 }
 
 func (m *ChainConfig) Reset()         { *m = ChainConfig{} }
@@ -67,7 +69,16 @@ func (m *ChainConfig) XXX_DiscardUnknown() {
 var xxx_messageInfo_ChainConfig proto.InternalMessageInfo
 
 type ProverConfig struct {
+	// defaults to "336h", which is 2/3 of the default unbonding period of 21 days
 	TrustingPeriod string `protobuf:"bytes,1,opt,name=trusting_period,json=trustingPeriod,proto3" json:"trusting_period,omitempty"`
+	// fraction of the validator set that must sign a header, between "1/3" (default) and "1/1"
+	TrustLevel string `protobuf:"bytes,2,opt,name=trust_level,json=trustLevel,proto3" json:"trust_level,omitempty"`
+	// defaults to "10m"
+	MaxClockDrift string `protobuf:"bytes,3,opt,name=max_clock_drift,json=maxClockDrift,proto3" json:"max_clock_drift,omitempty"`
+	// defaults to ["upgrade", "upgradedIBCState"]
+	UpgradePath                  []string `protobuf:"bytes,4,rep,name=upgrade_path,json=upgradePath,proto3" json:"upgrade_path,omitempty"`
+	AllowUpdateAfterExpiry       bool     `protobuf:"varint,5,opt,name=allow_update_after_expiry,json=allowUpdateAfterExpiry,proto3" json:"allow_update_after_expiry,omitempty"`
+	AllowUpdateAfterMisbehaviour bool     `protobuf:"varint,6,opt,name=allow_update_after_misbehaviour,json=allowUpdateAfterMisbehaviour,proto3" json:"allow_update_after_misbehaviour,omitempty"`
 }
 
 func (m *ProverConfig) Reset()         { *m = ProverConfig{} }
@@ -113,29 +124,38 @@ func init() {
 }
 
 var fileDescriptor_5bf5311194a4143e = []byte{
-	// 340 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0xb1, 0x4e, 0xf3, 0x30,
-	0x10, 0xc7, 0xe3, 0xaf, 0x1f, 0x85, 0x1a, 0x5a, 0x50, 0xc4, 0x10, 0x90, 0x88, 0xaa, 0x4a, 0x40,
-	0x97, 0x26, 0x03, 0x03, 0x73, 0xe9, 0x84, 0xc4, 0x10, 0x75, 0x64, 0x89, 0x1c, 0xdb, 0x75, 0x5d,
-	0x1a, 0x3b, 0x3a, 0x3b, 0x88, 0xbe, 0x05, 0xcf, 0xc4, 0xd4, 0xb1, 0x23, 0x23, 0xb4, 0x2f, 0x82,
-	0xe2, 0x04, 0x98, 0xd8, 0xce, 0xbf, 0xfb, 0xdd, 0x49, 0x7f, 0x1f, 0xbe, 0x02, 0xbe, 0x24, 0x2b,
-	0x0e, 0x31, 0x9d, 0x13, 0xa9, 0x4c, 0x6c, 0xb9, 0x62, 0x1c, 0x72, 0xa9, 0x6c, 0x4c, 0xb5, 0x9a,
-	0x49, 0x11, 0x15, 0xa0, 0xad, 0xf6, 0xfb, 0x8d, 0x17, 0xd5, 0x5e, 0xf4, 0xeb, 0x45, 0xb5, 0x77,
-	0x7e, 0x2a, 0xb4, 0xd0, 0x4e, 0x8e, 0xab, 0xaa, 0x9e, 0x1b, 0xbc, 0x21, 0x7c, 0x38, 0xa9, 0x46,
-	0x26, 0xce, 0xf2, 0x4f, 0x70, 0xeb, 0x89, 0xaf, 0x02, 0xd4, 0x47, 0xc3, 0xce, 0xb4, 0x2a, 0xfd,
-	0x33, 0x7c, 0xe0, 0x76, 0xa6, 0x92, 0x05, 0xff, 0x1c, 0xde, 0x77, 0xef, 0x7b, 0x56, 0xb5, 0xa0,
-	0xa0, 0x29, 0x61, 0x0c, 0x82, 0x56, 0xdd, 0x82, 0x82, 0x8e, 0x19, 0x03, 0xff, 0x12, 0xf7, 0x08,
-	0xa5, 0xba, 0x54, 0x36, 0x2d, 0x80, 0xcf, 0xe4, 0x4b, 0xf0, 0xdf, 0x09, 0xdd, 0x86, 0x26, 0x0e,
-	0x56, 0x9a, 0x20, 0x26, 0x25, 0x6c, 0x51, 0x1a, 0x9b, 0x73, 0x65, 0x83, 0xbd, 0x3e, 0x1a, 0xa2,
-	0x69, 0x57, 0x10, 0x33, 0xfe, 0x81, 0xfe, 0x05, 0xc6, 0x95, 0x56, 0x80, 0xa4, 0xdc, 0x04, 0x6d,
-	0xb7, 0xa9, 0x23, 0x88, 0x49, 0x1c, 0x18, 0xdc, 0xe2, 0xa3, 0x04, 0xf4, 0x33, 0x87, 0x26, 0xc4,
-	0x35, 0x3e, 0xb6, 0x50, 0x1a, 0x2b, 0x95, 0x48, 0x0b, 0x0e, 0x52, 0xb3, 0x26, 0x50, 0xef, 0x1b,
-	0x27, 0x8e, 0xde, 0x2d, 0xd6, 0x9f, 0xa1, 0xb7, 0xde, 0x86, 0x68, 0xb3, 0x0d, 0xd1, 0xc7, 0x36,
-	0x44, 0xaf, 0xbb, 0xd0, 0xdb, 0xec, 0x42, 0xef, 0x7d, 0x17, 0x7a, 0x8f, 0x0f, 0x42, 0xda, 0x79,
-	0x99, 0x45, 0x54, 0xe7, 0x31, 0x23, 0x96, 0xb8, 0xd8, 0x4b, 0x92, 0xc5, 0x73, 0x02, 0xb9, 0x56,
-	0xab, 0x11, 0xd5, 0x26, 0xd7, 0x66, 0x94, 0x81, 0x64, 0x82, 0x8f, 0x18, 0xcf, 0x75, 0xfc, 0xe7,
-	0xb9, 0xb2, 0xb6, 0xfb, 0xf0, 0x9b, 0xaf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd8, 0xf0, 0xed, 0x84,
-	0xd2, 0x01, 0x00, 0x00,
+	// 488 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xcd, 0x6e, 0xd3, 0x4c,
+	0x14, 0x86, 0xe3, 0xa6, 0x5f, 0x9b, 0x4c, 0x9a, 0xb6, 0x1a, 0x7d, 0x42, 0x2e, 0x02, 0x37, 0x54,
+	0xa2, 0x64, 0x41, 0xe2, 0x45, 0x56, 0x2c, 0x43, 0xa8, 0x04, 0x12, 0x48, 0x91, 0x25, 0x36, 0x6c,
+	0x46, 0x93, 0x99, 0x63, 0x7b, 0xa8, 0xed, 0x19, 0x1d, 0x8f, 0x43, 0x72, 0x17, 0xdc, 0x04, 0xf7,
+	0xd2, 0x65, 0x97, 0x2c, 0x21, 0xb9, 0x01, 0x2e, 0x01, 0x79, 0x62, 0x7e, 0x8a, 0x60, 0x37, 0xf3,
+	0xbc, 0xcf, 0x79, 0xa5, 0x39, 0x1a, 0x72, 0x89, 0x90, 0xf1, 0x35, 0x60, 0x28, 0x52, 0xae, 0x8a,
+	0x32, 0xb4, 0x50, 0x48, 0xc0, 0x5c, 0x15, 0x36, 0x14, 0xba, 0x88, 0x55, 0x32, 0x36, 0xa8, 0xad,
+	0xa6, 0x83, 0xc6, 0x1b, 0xef, 0xbc, 0xf1, 0x2f, 0x6f, 0xbc, 0xf3, 0xee, 0xff, 0x9f, 0xe8, 0x44,
+	0x3b, 0x39, 0xac, 0x4f, 0xbb, 0xb9, 0x8b, 0x6f, 0x1e, 0xe9, 0xcd, 0xea, 0x91, 0x99, 0xb3, 0xe8,
+	0x29, 0x69, 0x5f, 0xc3, 0xda, 0xf7, 0x06, 0xde, 0xb0, 0x1b, 0xd5, 0x47, 0x7a, 0x46, 0x3a, 0xae,
+	0x93, 0x29, 0xe9, 0xef, 0x39, 0x7c, 0xe8, 0xee, 0xaf, 0x64, 0x1d, 0xa1, 0x11, 0x8c, 0x4b, 0x89,
+	0x7e, 0x7b, 0x17, 0xa1, 0x11, 0x53, 0x29, 0x91, 0x3e, 0x26, 0xc7, 0x5c, 0x08, 0x5d, 0x15, 0x96,
+	0x19, 0x84, 0x58, 0xad, 0xfc, 0x7d, 0x27, 0xf4, 0x1b, 0x3a, 0x77, 0xb0, 0xd6, 0x12, 0x5e, 0x32,
+	0x2e, 0xdf, 0x57, 0xa5, 0xcd, 0xa1, 0xb0, 0xfe, 0x7f, 0x03, 0x6f, 0xe8, 0x45, 0xfd, 0x84, 0x97,
+	0xd3, 0x9f, 0x90, 0x3e, 0x24, 0xa4, 0xd6, 0x0c, 0x2a, 0x01, 0xa5, 0x7f, 0xe0, 0x9a, 0xba, 0x09,
+	0x2f, 0xe7, 0x0e, 0xd0, 0xa7, 0x84, 0xde, 0x6d, 0x61, 0x08, 0xb1, 0x7f, 0xe8, 0xb4, 0xd3, 0x3b,
+	0x4d, 0x11, 0xc4, 0x17, 0x9f, 0xf6, 0xc8, 0xd1, 0x1c, 0xf5, 0x12, 0xb0, 0x79, 0xf3, 0x13, 0x72,
+	0x62, 0xb1, 0x2a, 0xad, 0x2a, 0x12, 0x66, 0x00, 0x95, 0x96, 0xcd, 0xfb, 0x8f, 0x7f, 0xe0, 0xb9,
+	0xa3, 0xf4, 0x9c, 0xf4, 0x1c, 0x61, 0x19, 0x2c, 0x21, 0x6b, 0xb6, 0x41, 0x1c, 0x7a, 0x5d, 0x13,
+	0x7a, 0x49, 0x4e, 0x72, 0xbe, 0x62, 0x22, 0xd3, 0xe2, 0x9a, 0x49, 0x54, 0xb1, 0x6d, 0xf6, 0xd2,
+	0xcf, 0xf9, 0x6a, 0x56, 0xd3, 0x17, 0x35, 0xa4, 0x8f, 0xc8, 0x51, 0x65, 0x12, 0xe4, 0x12, 0x98,
+	0xe1, 0x36, 0xf5, 0xf7, 0x07, 0xed, 0x61, 0x37, 0xea, 0x35, 0x6c, 0xce, 0x6d, 0x4a, 0x9f, 0x91,
+	0x33, 0x9e, 0x65, 0xfa, 0x03, 0xab, 0x8c, 0xe4, 0x16, 0x18, 0x8f, 0x2d, 0x20, 0x83, 0x95, 0x51,
+	0xb8, 0x76, 0x4b, 0xea, 0x44, 0xf7, 0x9c, 0xf0, 0xd6, 0xe5, 0xd3, 0x3a, 0xbe, 0x72, 0x29, 0xbd,
+	0x22, 0xe7, 0x7f, 0x19, 0xcd, 0x55, 0xb9, 0x80, 0x94, 0x2f, 0x95, 0xae, 0xd0, 0xad, 0xb0, 0x13,
+	0x3d, 0xf8, 0xb3, 0xe0, 0xcd, 0x6f, 0xce, 0xf3, 0xf8, 0xe6, 0x6b, 0xd0, 0xba, 0xd9, 0x04, 0xde,
+	0xed, 0x26, 0xf0, 0xbe, 0x6c, 0x02, 0xef, 0xe3, 0x36, 0x68, 0xdd, 0x6e, 0x83, 0xd6, 0xe7, 0x6d,
+	0xd0, 0x7a, 0xf7, 0x32, 0x51, 0x36, 0xad, 0x16, 0x63, 0xa1, 0xf3, 0x30, 0xe7, 0x46, 0xc2, 0x72,
+	0x32, 0x09, 0x53, 0x8e, 0xb9, 0x2e, 0xd6, 0x23, 0xa1, 0xcb, 0x5c, 0x97, 0xa3, 0x05, 0x2a, 0x99,
+	0xc0, 0x48, 0x42, 0xae, 0xc3, 0x7f, 0xfe, 0xe3, 0xc5, 0x81, 0xfb, 0x89, 0x93, 0xef, 0x03, 0x00,
+	0x97, 0x4c, 0x69, 0x2d, 0xeb, 0x02, 0x00, 0x00,
 }
 
 func (m *ChainConfig) Marshal() (dAtA []byte, err error) {
@@ -158,6 +178,13 @@ func (m *ChainConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.GasAdjustmentRef) > 0 {
+		i -= len(m.GasAdjustmentRef)
+		copy(dAtA[i:], m.GasAdjustmentRef)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.GasAdjustmentRef)))
+		i--
+		dAtA[i] = 0x3a
+	}
 	if len(m.GasPrices) > 0 {
 		i -= len(m.GasPrices)
 		copy(dAtA[i:], m.GasPrices)
@@ -222,6 +249,49 @@ func (m *ProverConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.AllowUpdateAfterMisbehaviour {
+		i--
+		if m.AllowUpdateAfterMisbehaviour {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.AllowUpdateAfterExpiry {
+		i--
+		if m.AllowUpdateAfterExpiry {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.UpgradePath) > 0 {
+		for iNdEx := len(m.UpgradePath) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.UpgradePath[iNdEx])
+			copy(dAtA[i:], m.UpgradePath[iNdEx])
+			i = encodeVarintConfig(dAtA, i, uint64(len(m.UpgradePath[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.MaxClockDrift) > 0 {
+		i -= len(m.MaxClockDrift)
+		copy(dAtA[i:], m.MaxClockDrift)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.MaxClockDrift)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.TrustLevel) > 0 {
+		i -= len(m.TrustLevel)
+		copy(dAtA[i:], m.TrustLevel)
+		i = encodeVarintConfig(dAtA, i, uint64(len(m.TrustLevel)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.TrustingPeriod) > 0 {
 		i -= len(m.TrustingPeriod)
 		copy(dAtA[i:], m.TrustingPeriod)
@@ -272,6 +342,10 @@ func (m *ChainConfig) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovConfig(uint64(l))
 	}
+	l = len(m.GasAdjustmentRef)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
 	return n
 }
 
@@ -285,6 +359,26 @@ func (m *ProverConfig) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovConfig(uint64(l))
 	}
+	l = len(m.TrustLevel)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	l = len(m.MaxClockDrift)
+	if l > 0 {
+		n += 1 + l + sovConfig(uint64(l))
+	}
+	if len(m.UpgradePath) > 0 {
+		for _, s := range m.UpgradePath {
+			l = len(s)
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
+	if m.AllowUpdateAfterExpiry {
+		n += 2
+	}
+	if m.AllowUpdateAfterMisbehaviour {
+		n += 2
+	}
 	return n
 }
 
@@ -494,6 +588,38 @@ func (m *ChainConfig) Unmarshal(dAtA []byte) error {
 			}
 			m.GasPrices = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasAdjustmentRef", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GasAdjustmentRef = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -579,6 +705,142 @@ func (m *ProverConfig) Unmarshal(dAtA []byte) error {
 			}
 			m.TrustingPeriod = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrustLevel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TrustLevel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxClockDrift", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MaxClockDrift = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpgradePath", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConfig
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConfig
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UpgradePath = append(m.UpgradePath, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowUpdateAfterExpiry", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AllowUpdateAfterExpiry = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowUpdateAfterMisbehaviour", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConfig
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AllowUpdateAfterMisbehaviour = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConfig(dAtA[iNdEx:])
@@ -0,0 +1,114 @@
+package tendermint
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tmclient "github.com/cosmos/ibc-go/modules/light-clients/07-tendermint/types"
+)
+
+func TestTrustLevelFraction(t *testing.T) {
+	cases := []struct {
+		trustLevel string
+		want       tmclient.Fraction
+		ok         bool
+	}{
+		{"", tmclient.Fraction{Numerator: 1, Denominator: 3}, true},
+		{"1/3", tmclient.Fraction{Numerator: 1, Denominator: 3}, true},
+		{"2/3", tmclient.Fraction{Numerator: 2, Denominator: 3}, true},
+		{"1/1", tmclient.Fraction{Numerator: 1, Denominator: 1}, true},
+		{"1/3abc", tmclient.Fraction{}, false},
+		{"1 /3", tmclient.Fraction{}, false},
+		{"-1/3", tmclient.Fraction{}, false},
+		{"1/3/4", tmclient.Fraction{}, false},
+		{"1", tmclient.Fraction{}, false},
+		{"1/0", tmclient.Fraction{}, false},
+		{"0/0", tmclient.Fraction{}, false},
+		{"1/4", tmclient.Fraction{}, false},
+		{"4/3", tmclient.Fraction{}, false},
+	}
+	for _, c := range cases {
+		f, err := ProverConfig{TrustLevel: c.trustLevel}.TrustLevelFraction()
+		if c.ok != (err == nil) {
+			t.Errorf("%q: unexpected error: %v", c.trustLevel, err)
+			continue
+		}
+		if f != c.want {
+			t.Errorf("%q: unexpected fraction: %v", c.trustLevel, f)
+		}
+	}
+}
+
+func TestProverConfigValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		config ProverConfig
+		ok     bool
+	}{
+		{"defaults", ProverConfig{}, true},
+		{"all set", ProverConfig{TrustingPeriod: "120h", TrustLevel: "2/3", MaxClockDrift: "5s"}, true},
+		{"invalid trusting_period", ProverConfig{TrustingPeriod: "14d"}, false},
+		{"invalid trust_level", ProverConfig{TrustLevel: "1/3abc"}, false},
+		{"invalid max_clock_drift", ProverConfig{MaxClockDrift: "10"}, false},
+	}
+	for _, c := range cases {
+		if err := c.config.Validate(); c.ok != (err == nil) {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+		}
+	}
+
+	// the durations default when they are empty
+	var c ProverConfig
+	if d, _ := c.TrustingPeriodDuration(); d != defaultTrustingPeriod {
+		t.Errorf("unexpected default trusting period: %v", d)
+	}
+	if d, _ := c.MaxClockDriftDuration(); d != defaultMaxClockDrift {
+		t.Errorf("unexpected default max clock drift: %v", d)
+	}
+	c.TrustingPeriod = "120h"
+	if d, _ := c.TrustingPeriodDuration(); d != 120*time.Hour {
+		t.Errorf("unexpected trusting period: %v", d)
+	}
+}
+
+func TestChainConfigResolve(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "mnemonic")
+	if err := ioutil.WriteFile(keyFile, []byte("test mnemonic\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TM_TEST_RPC_ADDR", "http://localhost:26657")
+	t.Setenv("TM_TEST_GAS_ADJUSTMENT", "1.5")
+
+	c := ChainConfig{
+		Key:              "file://" + keyFile,
+		ChainId:          "${TM_TEST_CHAIN_ID}",
+		RpcAddr:          "${TM_TEST_RPC_ADDR}",
+		GasAdjustment:    1.1,
+		GasPrices:        "0.025stake",
+		GasAdjustmentRef: "${TM_TEST_GAS_ADJUSTMENT}",
+	}
+	resolved, err := c.Resolve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the chain id isn't a reference, and the raw config is left as it is
+	if resolved.Key != "test mnemonic" || resolved.RpcAddr != "http://localhost:26657" || resolved.ChainId != c.ChainId ||
+		resolved.GasPrices != "0.025stake" || resolved.GasAdjustment != 1.5 || resolved.GasAdjustmentRef != "" {
+		t.Fatalf("unexpected config: %+v", resolved)
+	}
+	if c.Key != "file://"+keyFile || c.GasAdjustment != 1.1 {
+		t.Fatalf("the raw config is modified: %+v", c)
+	}
+
+	c.GasPrices = "${TM_TEST_UNSET}stake"
+	if _, err := c.Resolve(); err == nil || !strings.Contains(err.Error(), "gas_prices") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.GasPrices, c.GasAdjustmentRef = "", "${TM_TEST_RPC_ADDR}"
+	if _, err := c.Resolve(); err == nil || !strings.Contains(err.Error(), "gas_adjustment_ref") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
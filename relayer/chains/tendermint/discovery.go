@@ -0,0 +1,53 @@
+package tendermint
+
+import (
+	"context"
+	"time"
+
+	sdkCtx "github.com/cosmos/cosmos-sdk/client"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// defaultGasPriceAmount is the gas price in the bond denom used for generated configs.
+// The minimum gas prices of a node are its local settings, which can't be queried.
+const defaultGasPriceAmount = "0.025"
+
+// NodeParams are the parameters of a chain discovered from its node
+type NodeParams struct {
+	ChainID         string
+	UnbondingPeriod time.Duration
+	BondDenom       string
+}
+
+// DiscoverNodeParams queries the chain ID from /status and the staking params of the node at the given address
+func DiscoverNodeParams(ctx context.Context, rpcAddr string, timeout time.Duration) (*NodeParams, error) {
+	client, err := newRPCClient(rpcAddr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	status, err := client.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	queryClient := stakingtypes.NewQueryClient(sdkCtx.Context{}.WithClient(client))
+	res, err := queryClient.Params(ctx, &stakingtypes.QueryParamsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return &NodeParams{
+		ChainID:         status.NodeInfo.Network,
+		UnbondingPeriod: res.Params.UnbondingTime,
+		BondDenom:       res.Params.BondDenom,
+	}, nil
+}
+
+// TrustingPeriod returns 2/3 of the unbonding period,
+// which leaves time to submit misbehaviour before the validators who signed it can unbond
+func (p NodeParams) TrustingPeriod() time.Duration {
+	return (p.UnbondingPeriod * 2 / 3).Truncate(time.Second)
+}
+
+// GasPrices returns the default gas prices in the bond denom
+func (p NodeParams) GasPrices() string {
+	return defaultGasPriceAmount + p.BondDenom
+}
@@ -0,0 +1,95 @@
+package tendermint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/p2p"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// newTestNode starts a JSON-RPC server which answers /status and the staking params query
+func newTestNode(t *testing.T, chainID string, params stakingtypes.Params) *httptest.Server {
+	paramsRes, err := (&stakingtypes.QueryParamsResponse{Params: params}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpctypes.RPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var res rpctypes.RPCResponse
+		switch req.Method {
+		case "status":
+			res = rpctypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultStatus{
+				NodeInfo: p2p.DefaultNodeInfo{Network: chainID},
+			})
+		case "abci_query":
+			var q struct {
+				Path string `json:"path"`
+			}
+			if err := json.Unmarshal(req.Params, &q); err != nil || q.Path != "/cosmos.staking.v1beta1.Query/Params" {
+				res = rpctypes.NewRPCErrorResponse(req.ID, -32602, "Invalid params", string(req.Params))
+				break
+			}
+			res = rpctypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultABCIQuery{
+				Response: abci.ResponseQuery{Value: paramsRes, Height: 1},
+			})
+		default:
+			res = rpctypes.RPCMethodNotFoundError(req.ID)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDiscoverNodeParams(t *testing.T) {
+	node := newTestNode(t, "ibc0", stakingtypes.Params{UnbondingTime: 21 * 24 * time.Hour, BondDenom: "uatom"})
+
+	params, err := DiscoverNodeParams(context.Background(), node.URL, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.ChainID != "ibc0" || params.UnbondingPeriod != 21*24*time.Hour || params.BondDenom != "uatom" {
+		t.Fatalf("unexpected params: %+v", params)
+	}
+	if tp := params.TrustingPeriod(); tp != 336*time.Hour || tp != defaultTrustingPeriod {
+		t.Errorf("unexpected trusting period: %v", tp)
+	}
+	if gp := params.GasPrices(); gp != "0.025uatom" {
+		t.Errorf("unexpected gas prices: %v", gp)
+	}
+
+	node.Close()
+	if _, err := DiscoverNodeParams(context.Background(), node.URL, time.Second); err == nil {
+		t.Fatal("expected an error for an unreachable node")
+	}
+}
+
+func TestNodeParamsTrustingPeriod(t *testing.T) {
+	for _, c := range []struct {
+		unbonding time.Duration
+		want      time.Duration
+	}{
+		{21 * 24 * time.Hour, 336 * time.Hour},
+		{3 * time.Second, 2 * time.Second},
+		// truncated to seconds
+		{1 * time.Second, 0},
+		{100 * time.Second, 66 * time.Second},
+	} {
+		if tp := (NodeParams{UnbondingPeriod: c.unbonding}).TrustingPeriod(); tp != c.want {
+			t.Errorf("unexpected trusting period for %v: %v, want %v", c.unbonding, tp, c.want)
+		}
+	}
+}
@@ -8,10 +8,15 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"time"
 
 	"github.com/avast/retry-go"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	"github.com/cosmos/ibc-go/modules/core/exported"
+	tmclient "github.com/cosmos/ibc-go/modules/light-clients/07-tendermint/types"
 	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/tendermint/types"
 	"github.com/tendermint/tendermint/libs/log"
+	tmmath "github.com/tendermint/tendermint/libs/math"
 	"github.com/tendermint/tendermint/light"
 	lightp "github.com/tendermint/tendermint/light/provider"
 	lighthttp "github.com/tendermint/tendermint/light/provider/http"
@@ -170,6 +175,97 @@ func (pr *Prover) GetLightSignedHeaderAtHeight(height int64) (*types.TmHeader, e
 	}, nil
 }
 
+// updateTrace returns the light blocks that the counterparty client has to verify in order to move
+// from trustedHeight to the given header, whose light block is the last element of the trace.
+// If the trusted validators have signed enough of the header, no light block is fetched and the header alone is returned.
+// Otherwise the trace is bisected from the light block at trustedHeight.
+func (pr *Prover) updateTrace(cs exported.ClientState, trustedHeight clienttypes.Height, trustedVals *tmtypes.ValidatorSet, header *types.TmHeader) ([]*tmtypes.LightBlock, error) {
+	target := &tmtypes.LightBlock{
+		SignedHeader: header.SignedHeader.SignedHeader(),
+		ValidatorSet: header.ValidatorSet.ValidatorSet(),
+	}
+	trustLevel, trustingPeriod, maxClockDrift := pr.verificationParams(cs)
+	if target.Height <= int64(trustedHeight.GetRevisionHeight()) ||
+		trustedVals.VerifyCommitLightTrusting(pr.chain.config.ChainId, target.Commit, trustLevel) == nil {
+		return []*tmtypes.LightBlock{target}, nil
+	}
+
+	ctx := context.Background()
+	prov := pr.LightHTTP()
+	trusted, err := prov.LightBlock(ctx, int64(trustedHeight.GetRevisionHeight()))
+	if err != nil {
+		return nil, err
+	}
+	return bisectionTrace(ctx, prov, trusted, target, trustLevel, trustingPeriod, maxClockDrift, time.Now())
+}
+
+// lightBlockProvider provides the light blocks of intermediate heights to bisectionTrace
+type lightBlockProvider interface {
+	LightBlock(ctx context.Context, height int64) (*tmtypes.LightBlock, error)
+}
+
+// bisectionTrace returns the light blocks that a client trusting the trusted block has to verify in order to move
+// to the target block. It follows the skipping verification of the tendermint light client:
+// while the validator set of the untrusted block can't be trusted, the range to verify is halved.
+// The last element of the trace is always the target block.
+func bisectionTrace(
+	ctx context.Context,
+	prov lightBlockProvider,
+	trusted, target *tmtypes.LightBlock,
+	trustLevel tmmath.Fraction,
+	trustingPeriod, maxClockDrift time.Duration,
+	now time.Time,
+) ([]*tmtypes.LightBlock, error) {
+	if target.Height <= trusted.Height {
+		return []*tmtypes.LightBlock{target}, nil
+	}
+	var (
+		trace []*tmtypes.LightBlock
+		cache = []*tmtypes.LightBlock{target}
+		depth = 0
+	)
+	for {
+		err := light.Verify(trusted.SignedHeader, trusted.ValidatorSet, cache[depth].SignedHeader, cache[depth].ValidatorSet,
+			trustingPeriod, now, maxClockDrift, trustLevel)
+		switch err.(type) {
+		case nil:
+			trace = append(trace, cache[depth])
+			// the target block has been verified
+			if depth == 0 {
+				return trace, nil
+			}
+			// continue from the verified block towards the target block
+			trusted = cache[depth]
+			cache = cache[:depth]
+			depth = 0
+		case light.ErrNewValSetCantBeTrusted:
+			if depth == len(cache)-1 {
+				pivot := (trusted.Height + cache[depth].Height) / 2
+				if pivot == trusted.Height {
+					return nil, fmt.Errorf("failed to bisect between heights %d and %d: %w", trusted.Height, cache[depth].Height, err)
+				}
+				lb, err := prov.LightBlock(ctx, pivot)
+				if err != nil {
+					return nil, err
+				}
+				cache = append(cache, lb)
+			}
+			depth++
+		default:
+			return nil, fmt.Errorf("failed to verify header at height %d from height %d: %w", cache[depth].Height, trusted.Height, err)
+		}
+	}
+}
+
+// verificationParams returns the parameters used by the counterparty client to verify headers.
+// If the client state isn't a tendermint one, the parameters used for creating a client are returned.
+func (pr *Prover) verificationParams(cs exported.ClientState) (trustLevel tmmath.Fraction, trustingPeriod, maxClockDrift time.Duration) {
+	if tmcs, ok := cs.(*tmclient.ClientState); ok {
+		return tmcs.TrustLevel.ToTendermint(), tmcs.TrustingPeriod, tmcs.MaxClockDrift
+	}
+	return pr.getTrustLevel().ToTendermint(), pr.getTrustingPeriod(), pr.getMaxClockDrift()
+}
+
 func lightDir(home string) string {
 	return path.Join(home, "light")
 }
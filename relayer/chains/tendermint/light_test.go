@@ -0,0 +1,138 @@
+package tendermint
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
+)
+
+const testChainID = "ibc0"
+
+var testGenesisTime = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// testValidators is a validator set and the keys of its validators in the order of the set
+type testValidators struct {
+	set *tmtypes.ValidatorSet
+	pvs []tmtypes.PrivValidator
+}
+
+func newTestValidators(t *testing.T, n int) testValidators {
+	vals := make([]*tmtypes.Validator, n)
+	keys := make(map[string]tmtypes.PrivValidator, n)
+	for i := range vals {
+		pv := tmtypes.NewMockPV()
+		pk, err := pv.GetPubKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		vals[i] = tmtypes.NewValidator(pk, 10)
+		keys[pk.Address().String()] = pv
+	}
+	set := tmtypes.NewValidatorSet(vals)
+	// the commit is signed in the order of the set
+	pvs := make([]tmtypes.PrivValidator, n)
+	for i, v := range set.Validators {
+		pvs[i] = keys[v.Address.String()]
+	}
+	return testValidators{set: set, pvs: pvs}
+}
+
+// lightBlock returns a block at the given height signed by the validators, which hands over to next
+func (v testValidators) lightBlock(t *testing.T, height int64, next testValidators) *tmtypes.LightBlock {
+	header := &tmtypes.Header{
+		Version:            tmversion.Consensus{Block: version.BlockProtocol},
+		ChainID:            testChainID,
+		Height:             height,
+		Time:               testGenesisTime.Add(time.Duration(height) * time.Second),
+		ValidatorsHash:     v.set.Hash(),
+		NextValidatorsHash: next.set.Hash(),
+		ProposerAddress:    v.set.Validators[0].Address,
+	}
+	blockID := tmtypes.BlockID{
+		Hash:          header.Hash(),
+		PartSetHeader: tmtypes.PartSetHeader{Total: 1, Hash: tmhash.Sum([]byte("parts"))},
+	}
+	voteSet := tmtypes.NewVoteSet(testChainID, height, 1, tmproto.PrecommitType, v.set)
+	commit, err := tmtypes.MakeCommit(blockID, height, 1, voteSet, v.pvs, header.Time)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &tmtypes.LightBlock{
+		SignedHeader: &tmtypes.SignedHeader{Header: header, Commit: commit},
+		ValidatorSet: v.set,
+	}
+}
+
+// testProvider provides light blocks and records the heights fetched
+type testProvider struct {
+	blocks  map[int64]*tmtypes.LightBlock
+	fetched []int64
+}
+
+func (p *testProvider) LightBlock(_ context.Context, height int64) (*tmtypes.LightBlock, error) {
+	p.fetched = append(p.fetched, height)
+	lb, ok := p.blocks[height]
+	if !ok {
+		return nil, fmt.Errorf("no light block at height %d", height)
+	}
+	return lb, nil
+}
+
+func TestBisectionTrace(t *testing.T) {
+	// the validators are entirely replaced at height 6
+	a, b := newTestValidators(t, 4), newTestValidators(t, 4)
+	blocks := make(map[int64]*tmtypes.LightBlock)
+	for h := int64(1); h <= 10; h++ {
+		switch {
+		case h < 5:
+			blocks[h] = a.lightBlock(t, h, a)
+		case h == 5:
+			blocks[h] = a.lightBlock(t, h, b)
+		default:
+			blocks[h] = b.lightBlock(t, h, b)
+		}
+	}
+
+	cases := []struct {
+		name    string
+		trusted int64
+		target  int64
+		trace   []int64
+		fetched []int64
+	}{
+		// the new validators can't be trusted, but an adjacent header is verified by the next validators hash
+		{"adjacent header", 5, 6, []int64{6}, nil},
+		// the trusted validators have signed the target
+		{"skipping header", 1, 4, []int64{4}, nil},
+		// none of the trusted validators have signed the target
+		{"bisection", 1, 10, []int64{5, 6, 10}, []int64{5, 7, 6}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			prov := &testProvider{blocks: blocks}
+			trace, err := bisectionTrace(context.Background(), prov, blocks[c.trusted], blocks[c.target],
+				tmmath.Fraction{Numerator: 1, Denominator: 3}, 24*time.Hour, 10*time.Second, testGenesisTime.Add(time.Hour))
+			if err != nil {
+				t.Fatal(err)
+			}
+			heights := make([]int64, len(trace))
+			for i, lb := range trace {
+				heights[i] = lb.Height
+			}
+			if fmt.Sprint(heights) != fmt.Sprint(c.trace) {
+				t.Errorf("unexpected trace: %v, want %v", heights, c.trace)
+			}
+			if fmt.Sprint(prov.fetched) != fmt.Sprint(c.fetched) {
+				t.Errorf("unexpected fetched heights: %v, want %v", prov.fetched, c.fetched)
+			}
+		})
+	}
+}
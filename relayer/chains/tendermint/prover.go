@@ -12,6 +12,7 @@ import (
 	chantypes "github.com/cosmos/ibc-go/modules/core/04-channel/types"
 	"github.com/cosmos/ibc-go/modules/core/exported"
 	ibcexported "github.com/cosmos/ibc-go/modules/core/exported"
+	tmclient "github.com/cosmos/ibc-go/modules/light-clients/07-tendermint/types"
 	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/tendermint/types"
 	"github.com/tendermint/tendermint/light"
 	tmtypes "github.com/tendermint/tendermint/types"
@@ -107,15 +108,63 @@ func (pr *Prover) CreateMsgCreateClient(clientID string, dstHeader core.HeaderI,
 
 	return createClient(
 		tmHeader,
-		pr.getTrustingPeriod(),
+		pr.config,
 		ubdPeriod,
 		consensusParams,
 		signer,
-	), nil
+	)
 }
 
-// SetupHeader creates a new header based on a given header
+// SetupHeader creates a new header based on a given header.
+// If the counterparty client can't verify the given header from its latest height in a single step,
+// the intermediate headers of the bisection trace are submitted to the counterparty first,
+// so that the client can verify the returned header after them.
 func (pr *Prover) SetupHeader(dstChain core.LightClientIBCQueryierI, srcHeader core.HeaderI) (core.HeaderI, error) {
+	headers, err := pr.SetupHeadersForUpdate(dstChain, srcHeader)
+	if err != nil {
+		return nil, err
+	}
+	if len(headers) > 1 {
+		if err := pr.submitHeaders(dstChain, headers[:len(headers)-1]); err != nil {
+			return nil, err
+		}
+	}
+	return headers[len(headers)-1], nil
+}
+
+// headerSubmitter is a counterparty chain to which headers can be submitted, such as core.ProvableChain
+type headerSubmitter interface {
+	GetAddress() (sdk.AccAddress, error)
+	Path() *core.PathEnd
+	SendMsgs(msgs []sdk.Msg) ([]byte, error)
+}
+
+// submitHeaders updates the client on the counterparty chain with the given headers in order
+func (pr *Prover) submitHeaders(dstChain core.LightClientIBCQueryierI, headers []core.HeaderI) error {
+	dst, ok := dstChain.(headerSubmitter)
+	if !ok {
+		return fmt.Errorf("%d intermediate headers of %s are needed, but they can't be submitted to the counterparty, run `rly tendermint update-client` first",
+			len(headers), pr.chain.ChainID())
+	}
+	signer, err := dst.GetAddress()
+	if err != nil {
+		return err
+	}
+	msgs := make([]sdk.Msg, len(headers))
+	for i, h := range headers {
+		msgs[i] = dst.Path().UpdateClient(h, signer)
+	}
+	if _, err := dst.SendMsgs(msgs); err != nil {
+		return fmt.Errorf("failed to submit %d intermediate headers of %s: %w", len(headers), pr.chain.ChainID(), err)
+	}
+	pr.chain.Log(fmt.Sprintf("- [%s] submitted %d intermediate headers to the client %s", pr.chain.ChainID(), len(headers), dst.Path().ClientID))
+	return nil
+}
+
+// SetupHeadersForUpdate returns the whole bisection trace, which is the headers that the counterparty client
+// has to verify in order to move from its latest height to the height of a given header.
+// The last header is the given one.
+func (pr *Prover) SetupHeadersForUpdate(dstChain core.LightClientIBCQueryierI, srcHeader core.HeaderI) ([]core.HeaderI, error) {
 	srcChain := pr.chain
 	// make copy of header stored in mop
 	tmp := srcHeader.(*types.TmHeader)
@@ -137,20 +186,46 @@ func (pr *Prover) SetupHeader(dstChain core.LightClientIBCQueryierI, srcHeader c
 		return nil, err
 	}
 
-	// inject TrustedHeight as latest height stored on counterparty client
+	// the latest height stored on counterparty client is the first trusted height
 	trustedHeight := cs.GetLatestHeight().(clienttypes.Height)
-	th := types.Height(trustedHeight)
-	h.TrustedHeight = &th
-
 	// query TrustedValidators at Trusted Height from srcChain
-	valSet, err := srcChain.QueryValsetAtHeight(trustedHeight)
+	trustedVals, err := srcChain.QueryValsetAtHeight(trustedHeight)
+	if err != nil {
+		return nil, err
+	}
+	trace, err := pr.updateTrace(cs, trustedHeight, trustedVals, &h)
 	if err != nil {
 		return nil, err
 	}
 
-	// inject TrustedValidators into header
-	h.TrustedValidators = types.NewValidatorSetFromTm(valSet)
-	return &h, nil
+	headers := make([]core.HeaderI, len(trace))
+	for i, lb := range trace {
+		var header types.TmHeader
+		if i == len(trace)-1 {
+			header = h
+		} else {
+			header = types.TmHeader{
+				SignedHeader: types.NewSignedHeaderFromTm(lb.SignedHeader),
+				ValidatorSet: types.NewValidatorSetFromTm(lb.ValidatorSet),
+			}
+		}
+
+		// inject TrustedHeight as the height verified by the previous update
+		th := types.Height(trustedHeight)
+		header.TrustedHeight = &th
+
+		if i > 0 {
+			if trustedVals, err = srcChain.QueryValsetAtHeight(trustedHeight); err != nil {
+				return nil, err
+			}
+		}
+		// inject TrustedValidators into header
+		header.TrustedValidators = types.NewValidatorSetFromTm(trustedVals)
+		headers[i] = &header
+
+		trustedHeight = clienttypes.NewHeight(trustedHeight.GetRevisionNumber(), uint64(lb.Height))
+	}
+	return headers, nil
 }
 
 func lightError(err error) error { return fmt.Errorf("light client: %w", err) }
@@ -205,10 +280,22 @@ func (pr *Prover) TrustOptions(height int64, hash []byte) light.TrustOptions {
 
 // getTrustingPeriod returns the trusting period for the chain
 func (pr *Prover) getTrustingPeriod() time.Duration {
-	tp, _ := time.ParseDuration(pr.config.TrustingPeriod)
+	tp, _ := pr.config.TrustingPeriodDuration()
 	return tp
 }
 
+// getTrustLevel returns the trust level for the chain
+func (pr *Prover) getTrustLevel() tmclient.Fraction {
+	tl, _ := pr.config.TrustLevelFraction()
+	return tl
+}
+
+// getMaxClockDrift returns the max clock drift for the chain
+func (pr *Prover) getMaxClockDrift() time.Duration {
+	d, _ := pr.config.MaxClockDriftDuration()
+	return d
+}
+
 // queryHeaderAtHeight returns the header at a given height
 func (c *Prover) queryHeaderAtHeight(height int64) (*types.TmHeader, error) {
 	var (
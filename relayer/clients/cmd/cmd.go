@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/clients"
+	"github.com/mapdev33/yui-relayer/config"
+	"github.com/spf13/cobra"
+)
+
+func ClientsCmd(ctx *config.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clients",
+		Short: "monitor light clients on both chains of a path",
+	}
+
+	cmd.AddCommand(
+		statusCmd(ctx),
+		watchCmd(ctx),
+		substituteCmd(ctx),
+	)
+
+	return cmd
+}
+
+func statusCmd(ctx *config.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status [path-name]",
+		Short: "show the time to expiry of the clients on both chains of a path",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, src, dst, err := ctx.Config.ChainsFromPath(args[0])
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			for _, id := range []string{src, dst} {
+				status, err := clients.QueryClientStatus(c[id], now)
+				if err != nil {
+					return fmt.Errorf("failed to query client status on %s: %w", id, err)
+				}
+				fmt.Println(status)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+func watchCmd(ctx *config.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch [path-name]",
+		Short: "update the clients on both chains of a path before they expire",
+		Long: "Check the clients on both chains of a path periodically," +
+			" and update a client once the given fraction of its trusting period has elapsed",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, src, dst, err := ctx.Config.ChainsFromPath(args[0])
+			if err != nil {
+				return err
+			}
+			threshold, err := cmd.Flags().GetFloat64(flagThreshold)
+			if err != nil {
+				return err
+			}
+			if threshold <= 0 || threshold >= 1 {
+				return fmt.Errorf("threshold must be between 0 and 1, but got %v", threshold)
+			}
+			interval, err := cmd.Flags().GetDuration(flagInterval)
+			if err != nil {
+				return err
+			}
+
+			counterparty := map[string]string{src: dst, dst: src}
+			for {
+				for _, id := range []string{src, dst} {
+					status, err := clients.QueryClientStatus(c[id], time.Now())
+					if errors.Is(err, clients.ErrUnsupportedClientType) {
+						// retrying won't help, as the client type of a path doesn't change
+						return fmt.Errorf("failed to query client status on %s: %w", id, err)
+					} else if err != nil {
+						log.Printf("failed to query client status on %s: %v", id, err)
+						continue
+					}
+					log.Println(status)
+					if status.Expired() {
+						log.Printf("client %s on %s has expired and can't be updated", status.ClientID, id)
+						continue
+					}
+					if status.Consumed() < threshold {
+						continue
+					}
+					if err := clients.UpdateClient(c[id], c[counterparty[id]]); err != nil {
+						log.Printf("failed to update client %s on %s: %v", status.ClientID, id, err)
+						continue
+					}
+					log.Printf("updated client %s on %s", status.ClientID, id)
+				}
+				time.Sleep(interval)
+			}
+		},
+	}
+	return watchFlags(cmd)
+}
+
+func substituteCmd(ctx *config.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "substitute [path-name] [chain-id]",
+		Short: "create a substitute client on a chain of a path",
+		Long: "Create a new client of the counterparty on the given chain." +
+			" It can replace the expired or frozen client of the path through" +
+			" `tendermint client-update-proposal` or `harmony tx recover-client`",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, src, dst, err := ctx.Config.ChainsFromPath(args[0])
+			if err != nil {
+				return err
+			}
+			switch args[1] {
+			case src:
+			case dst:
+				src, dst = dst, src
+			default:
+				return fmt.Errorf("not found chain '%v' in the path", args[1])
+			}
+			res, err := clients.CreateSubstituteClient(c[src], c[dst])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("created a substitute client on %s\n", src)
+			if len(res) > 0 {
+				fmt.Println(string(res))
+			}
+			return nil
+		},
+	}
+	return cmd
+}
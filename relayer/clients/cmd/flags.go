@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagThreshold = "threshold"
+	flagInterval  = "interval"
+)
+
+func watchFlags(cmd *cobra.Command) *cobra.Command {
+	cmd.Flags().Float64(flagThreshold, 2.0/3.0, "fraction of the trusting period after which a client is updated")
+	cmd.Flags().Duration(flagInterval, time.Minute, "interval between checks")
+	return cmd
+}
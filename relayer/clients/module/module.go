@@ -0,0 +1,25 @@
+package module
+
+import (
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/clients/cmd"
+	"github.com/mapdev33/yui-relayer/config"
+	"github.com/spf13/cobra"
+)
+
+type Module struct{}
+
+var _ config.ModuleI = (*Module)(nil)
+
+// Name returns the name of the module
+func (Module) Name() string {
+	return "clients"
+}
+
+// RegisterInterfaces register the module interfaces to protobuf Any.
+func (Module) RegisterInterfaces(registry codectypes.InterfaceRegistry) {}
+
+// GetCmd returns the command
+func (Module) GetCmd(ctx *config.Context) *cobra.Command {
+	return cmd.ClientsCmd(ctx)
+}
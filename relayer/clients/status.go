@@ -0,0 +1,113 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/ibc-go/modules/core/exported"
+	tmclient "github.com/cosmos/ibc-go/modules/light-clients/07-tendermint/types"
+	hmylctypes "github.com/datachainlab/ibc-harmony-client/modules/light-clients/harmony/types"
+	"github.com/mapdev33/yui-relayer/core"
+)
+
+// ErrUnsupportedClientType is returned for a client whose trusting period can't be determined
+var ErrUnsupportedClientType = errors.New("unsupported client type")
+
+// ClientStatus represents how close a light client is to the end of its trusting period
+type ClientStatus struct {
+	ChainID        string        `json:"chain_id"`
+	ClientID       string        `json:"client_id"`
+	ClientType     string        `json:"client_type"`
+	LatestHeight   uint64        `json:"latest_height"`
+	LastUpdate     time.Time     `json:"last_update"`
+	TrustingPeriod time.Duration `json:"trusting_period"`
+	TimeToExpiry   time.Duration `json:"time_to_expiry"`
+}
+
+// Consumed returns the fraction of the trusting period elapsed since the last update
+func (s ClientStatus) Consumed() float64 {
+	if s.TrustingPeriod <= 0 {
+		return 1
+	}
+	return 1 - float64(s.TimeToExpiry)/float64(s.TrustingPeriod)
+}
+
+// Expired returns true if the trusting period has already elapsed
+func (s ClientStatus) Expired() bool {
+	return s.TimeToExpiry <= 0
+}
+
+func (s ClientStatus) String() string {
+	expiry := fmt.Sprintf("expires in %v", s.TimeToExpiry.Round(time.Second))
+	if s.Expired() {
+		expiry = fmt.Sprintf("expired %v ago", (-s.TimeToExpiry).Round(time.Second))
+	}
+	return fmt.Sprintf("[%s] client %s (%s): height %d, last update %v, trusting period %v, %s (%.1f%% consumed)",
+		s.ChainID, s.ClientID, s.ClientType, s.LatestHeight, s.LastUpdate.UTC().Format(time.RFC3339),
+		s.TrustingPeriod, expiry, s.Consumed()*100)
+}
+
+// QueryClientStatus returns the status of the client on a given chain at its latest height
+func QueryClientStatus(chain *core.ProvableChain, now time.Time) (*ClientStatus, error) {
+	height, err := chain.GetLatestHeight()
+	if err != nil {
+		return nil, err
+	}
+	csRes, err := chain.QueryClientState(height)
+	if err != nil {
+		return nil, err
+	}
+	var cs exported.ClientState
+	if err := chain.Codec().UnpackAny(csRes.ClientState, &cs); err != nil {
+		return nil, err
+	}
+	tp, err := trustingPeriod(cs)
+	if err != nil {
+		return nil, err
+	}
+	consRes, err := chain.QueryClientConsensusState(height, cs.GetLatestHeight())
+	if err != nil {
+		return nil, err
+	}
+	var cons exported.ConsensusState
+	if err := chain.Codec().UnpackAny(consRes.ConsensusState, &cons); err != nil {
+		return nil, err
+	}
+	lastUpdate, err := consensusTimestamp(cons)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientStatus{
+		ChainID:        chain.ChainID(),
+		ClientID:       chain.Path().ClientID,
+		ClientType:     cs.ClientType(),
+		LatestHeight:   cs.GetLatestHeight().GetRevisionHeight(),
+		LastUpdate:     lastUpdate,
+		TrustingPeriod: tp,
+		TimeToExpiry:   lastUpdate.Add(tp).Sub(now),
+	}, nil
+}
+
+func trustingPeriod(cs exported.ClientState) (time.Duration, error) {
+	switch cs := cs.(type) {
+	case *tmclient.ClientState:
+		return cs.TrustingPeriod, nil
+	case *hmylctypes.ClientState:
+		return cs.TrustingPeriod, nil
+	default:
+		return 0, fmt.Errorf("%w %T", ErrUnsupportedClientType, cs)
+	}
+}
+
+func consensusTimestamp(cons exported.ConsensusState) (time.Time, error) {
+	switch cons := cons.(type) {
+	case *tmclient.ConsensusState:
+		return cons.Timestamp, nil
+	case *hmylctypes.ConsensusState:
+		// harmony block timestamps are in seconds
+		return time.Unix(int64(cons.Timestamp), 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("%w %T", ErrUnsupportedClientType, cons)
+	}
+}
@@ -0,0 +1,137 @@
+package clients
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	clienttypes "github.com/cosmos/ibc-go/modules/core/02-client/types"
+	"github.com/cosmos/ibc-go/modules/core/exported"
+	tmclient "github.com/cosmos/ibc-go/modules/light-clients/07-tendermint/types"
+	localhosttypes "github.com/cosmos/ibc-go/modules/light-clients/09-localhost/types"
+	hmylctypes "github.com/datachainlab/ibc-harmony-client/modules/light-clients/harmony/types"
+	"github.com/mapdev33/yui-relayer/core"
+)
+
+var testNow = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func makeTestCodec() codec.ProtoCodecMarshaler {
+	registry := codectypes.NewInterfaceRegistry()
+	clienttypes.RegisterInterfaces(registry)
+	tmclient.RegisterInterfaces(registry)
+	localhosttypes.RegisterInterfaces(registry)
+	hmylctypes.RegisterInterfaces(registry)
+	return codec.NewProtoCodec(registry)
+}
+
+// testChain is a chain which only returns a client state and its latest consensus state
+type testChain struct {
+	core.ChainI
+	clientState    exported.ClientState
+	consensusState exported.ConsensusState
+}
+
+func (c testChain) ChainID() string {
+	return "ibc0"
+}
+
+func (c testChain) Path() *core.PathEnd {
+	return &core.PathEnd{ChainID: "ibc0", ClientID: "client-0"}
+}
+
+func (c testChain) Codec() codec.ProtoCodecMarshaler {
+	return makeTestCodec()
+}
+
+func (c testChain) GetLatestHeight() (int64, error) {
+	return 100, nil
+}
+
+func (c testChain) QueryClientState(height int64) (*clienttypes.QueryClientStateResponse, error) {
+	any, err := clienttypes.PackClientState(c.clientState)
+	if err != nil {
+		return nil, err
+	}
+	return clienttypes.NewQueryClientStateResponse(any, nil, clienttypes.NewHeight(0, uint64(height))), nil
+}
+
+func (c testChain) QueryClientConsensusState(height int64, dstClientConsHeight exported.Height) (*clienttypes.QueryConsensusStateResponse, error) {
+	any, err := clienttypes.PackConsensusState(c.consensusState)
+	if err != nil {
+		return nil, err
+	}
+	return clienttypes.NewQueryConsensusStateResponse(any, nil, clienttypes.NewHeight(0, uint64(height))), nil
+}
+
+func TestQueryClientStatus(t *testing.T) {
+	cases := []struct {
+		name         string
+		chain        testChain
+		lastUpdate   time.Time
+		timeToExpiry time.Duration
+		consumed     float64
+		expired      bool
+	}{
+		{
+			"tendermint",
+			testChain{
+				clientState:    &tmclient.ClientState{TrustingPeriod: 24 * time.Hour, LatestHeight: clienttypes.NewHeight(1, 10)},
+				consensusState: &tmclient.ConsensusState{Timestamp: testNow.Add(-6 * time.Hour)},
+			},
+			testNow.Add(-6 * time.Hour), 18 * time.Hour, 0.25, false,
+		},
+		{
+			"harmony",
+			testChain{
+				clientState:    &hmylctypes.ClientState{TrustingPeriod: 10 * time.Hour, LatestHeight: clienttypes.NewHeight(0, 10)},
+				consensusState: &hmylctypes.ConsensusState{Timestamp: uint64(testNow.Add(-12 * time.Hour).Unix())},
+			},
+			testNow.Add(-12 * time.Hour), -2 * time.Hour, 1.2, true,
+		},
+		{
+			"expires now",
+			testChain{
+				clientState:    &tmclient.ClientState{TrustingPeriod: time.Hour, LatestHeight: clienttypes.NewHeight(1, 10)},
+				consensusState: &tmclient.ConsensusState{Timestamp: testNow.Add(-time.Hour)},
+			},
+			testNow.Add(-time.Hour), 0, 1, true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			status, err := QueryClientStatus(&core.ProvableChain{ChainI: c.chain}, testNow)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.ClientID != "client-0" || status.LatestHeight != 10 || !status.LastUpdate.Equal(c.lastUpdate) {
+				t.Errorf("unexpected status: %+v", status)
+			}
+			if status.TimeToExpiry != c.timeToExpiry {
+				t.Errorf("unexpected time to expiry: %v", status.TimeToExpiry)
+			}
+			if d := status.Consumed() - c.consumed; d > 1e-9 || d < -1e-9 {
+				t.Errorf("unexpected consumed fraction: %v", status.Consumed())
+			}
+			if status.Expired() != c.expired {
+				t.Errorf("unexpected expiry: %v", status.Expired())
+			}
+		})
+	}
+}
+
+func TestQueryClientStatusOfUnsupportedClient(t *testing.T) {
+	chain := testChain{clientState: localhosttypes.NewClientState("ibc0", clienttypes.NewHeight(0, 10))}
+	_, err := QueryClientStatus(&core.ProvableChain{ChainI: chain}, testNow)
+	if !errors.Is(err, ErrUnsupportedClientType) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClientStatusWithoutTrustingPeriod(t *testing.T) {
+	s := ClientStatus{TimeToExpiry: -time.Second}
+	if s.Consumed() != 1 || !s.Expired() {
+		t.Fatalf("a client without a trusting period must be consumed and expired: %+v", s)
+	}
+}
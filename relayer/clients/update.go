@@ -0,0 +1,50 @@
+package clients
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/mapdev33/yui-relayer/core"
+)
+
+// UpdateClient updates the client on a given chain with the latest header of its counterparty
+func UpdateClient(chain, counterparty *core.ProvableChain) error {
+	latestHeader, _, _, err := counterparty.UpdateLightWithHeader()
+	if err != nil {
+		return err
+	}
+	header, err := counterparty.SetupHeader(chain, latestHeader)
+	if err != nil {
+		return err
+	}
+	signer, err := chain.GetAddress()
+	if err != nil {
+		return err
+	}
+	tx := core.RelayMsgs{
+		Src: []sdk.Msg{chain.Path().UpdateClient(header, signer)},
+		Dst: []sdk.Msg{},
+	}
+	if tx.Send(chain, counterparty); !tx.Succeeded {
+		return fmt.Errorf("failed to update client %s on %s", chain.Path().ClientID, chain.ChainID())
+	}
+	return nil
+}
+
+// CreateSubstituteClient creates a new client of counterparty on a given chain,
+// which can replace an expired or frozen client through a client recovery
+func CreateSubstituteClient(chain, counterparty *core.ProvableChain) ([]byte, error) {
+	latestHeader, _, _, err := counterparty.UpdateLightWithHeader()
+	if err != nil {
+		return nil, err
+	}
+	signer, err := chain.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	msg, err := counterparty.CreateMsgCreateClient("", latestHeader, signer)
+	if err != nil {
+		return nil, err
+	}
+	return chain.SendMsgs([]sdk.Msg{msg})
+}
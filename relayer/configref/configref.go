@@ -0,0 +1,117 @@
+// Package configref resolves references to environment variables and files in chain configs,
+// so that secrets such as private keys don't have to be written in the config files.
+package configref
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FilePrefix is the prefix of a value read from a file
+const FilePrefix = "file://"
+
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Resolve replaces each "${ENV}" in the value with the environment variable,
+// and then reads the value from the file if it starts with "file://", trimming surrounding whitespace.
+// Other values are returned as they are.
+// Errors never contain the resolved value, which may be a secret.
+func Resolve(value string) (string, error) {
+	var unset []string
+	value = envPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		name := envPattern.FindStringSubmatch(ref)[1]
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			unset = append(unset, name)
+		}
+		return v
+	})
+	if len(unset) > 0 {
+		return "", fmt.Errorf("unset environment variables: %s", strings.Join(unset, ", "))
+	}
+	if !strings.HasPrefix(value, FilePrefix) {
+		return value, nil
+	}
+	path := strings.TrimPrefix(value, FilePrefix)
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		// the path may be resolved from environment variables, so only the cause is reported
+		var pathErr *os.PathError
+		if errors.As(err, &pathErr) {
+			err = pathErr.Err
+		}
+		return "", fmt.Errorf("failed to read the referenced file: %w", err)
+	}
+	return strings.TrimSpace(string(bz)), nil
+}
+
+// ResolveField resolves the value of a config field, and names the field in the error
+func ResolveField(name, value string) (string, error) {
+	v, err := Resolve(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", name, err)
+	}
+	return v, nil
+}
+
+// Field is a string field of a config
+type Field struct {
+	Name  string
+	Value *string
+}
+
+// ResolveFields resolves the values of the fields in place
+func ResolveFields(fields ...Field) error {
+	for _, f := range fields {
+		v, err := ResolveField(f.Name, *f.Value)
+		if err != nil {
+			return err
+		}
+		*f.Value = v
+	}
+	return nil
+}
+
+// ResolveUint64 resolves the reference to an unsigned integer field
+func ResolveUint64(name, ref string) (uint64, error) {
+	v, err := ResolveField(name, ref)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not an unsigned integer", name)
+	}
+	return n, nil
+}
+
+// ResolveInt64 resolves the reference to an integer field
+func ResolveInt64(name, ref string) (int64, error) {
+	v, err := ResolveField(name, ref)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not an integer", name)
+	}
+	return n, nil
+}
+
+// ResolveFloat64 resolves the reference to a floating point field
+func ResolveFloat64(name, ref string) (float64, error) {
+	v, err := ResolveField(name, ref)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a number", name)
+	}
+	return f, nil
+}
@@ -0,0 +1,127 @@
+package configref
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSecret = "0123456789abcdef"
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(dir, "key"), []byte("\n  "+testSecret+" \n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIGREF_KEY", testSecret)
+	t.Setenv("CONFIGREF_DIR", dir)
+	t.Setenv("CONFIGREF_EMPTY", "")
+
+	cases := []struct {
+		value string
+		want  string
+	}{
+		{"", ""},
+		{"plain", "plain"},
+		{"$CONFIGREF_KEY", "$CONFIGREF_KEY"},
+		{"${CONFIGREF_KEY}", testSecret},
+		{"0x${CONFIGREF_KEY}${CONFIGREF_EMPTY}", "0x" + testSecret},
+		{"file://" + filepath.Join(dir, "key"), testSecret},
+		{"file://${CONFIGREF_DIR}/key", testSecret},
+		// only a value starting with the prefix is read from a file
+		{"key file://" + filepath.Join(dir, "key"), "key file://" + filepath.Join(dir, "key")},
+	}
+	for _, c := range cases {
+		v, err := Resolve(c.value)
+		if err != nil {
+			t.Errorf("%q: %v", c.value, err)
+			continue
+		}
+		if v != c.want {
+			t.Errorf("%q: unexpected value: %q", c.value, v)
+		}
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("CONFIGREF_KEY", testSecret)
+	t.Setenv("CONFIGREF_DIR", filepath.Join(dir, testSecret))
+
+	cases := []struct {
+		value    string
+		contains string
+	}{
+		{"${CONFIGREF_UNSET_A}:${CONFIGREF_KEY}:${CONFIGREF_UNSET_B}", "CONFIGREF_UNSET_A, CONFIGREF_UNSET_B"},
+		{"file://${CONFIGREF_DIR}/key", "failed to read the referenced file"},
+		{"file://" + filepath.Join(dir, testSecret), "failed to read the referenced file"},
+	}
+	for _, c := range cases {
+		_, err := Resolve(c.value)
+		if err == nil {
+			t.Errorf("%q: expected an error", c.value)
+			continue
+		}
+		if !strings.Contains(err.Error(), c.contains) {
+			t.Errorf("%q: unexpected error: %v", c.value, err)
+		}
+		if strings.Contains(err.Error(), testSecret) {
+			t.Errorf("%q: the error contains the resolved value: %v", c.value, err)
+		}
+	}
+}
+
+func TestResolveFields(t *testing.T) {
+	t.Setenv("CONFIGREF_KEY", testSecret)
+	t.Setenv("CONFIGREF_ADDR", "http://localhost:26657")
+
+	key, addr, plain := "${CONFIGREF_KEY}", "${CONFIGREF_ADDR}", "plain"
+	if err := ResolveFields(
+		Field{Name: "key", Value: &key},
+		Field{Name: "rpc_addr", Value: &addr},
+		Field{Name: "plain", Value: &plain},
+	); err != nil {
+		t.Fatal(err)
+	}
+	if key != testSecret || addr != "http://localhost:26657" || plain != "plain" {
+		t.Fatalf("unexpected values: %q, %q, %q", key, addr, plain)
+	}
+
+	// the field is named in the error, and the fields before it are resolved
+	key, addr = "${CONFIGREF_KEY}", "${CONFIGREF_UNSET}"
+	err := ResolveFields(Field{Name: "key", Value: &key}, Field{Name: "rpc_addr", Value: &addr})
+	if err == nil || !strings.Contains(err.Error(), "rpc_addr") || !strings.Contains(err.Error(), "CONFIGREF_UNSET") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(err.Error(), testSecret) {
+		t.Fatalf("the error contains the resolved value: %v", err)
+	}
+}
+
+func TestResolveNumbers(t *testing.T) {
+	t.Setenv("CONFIGREF_NUMBER", "42")
+	t.Setenv("CONFIGREF_KEY", testSecret)
+
+	if n, err := ResolveUint64("gas_limit", "${CONFIGREF_NUMBER}"); err != nil || n != 42 {
+		t.Errorf("unexpected uint64: %v, %v", n, err)
+	}
+	if n, err := ResolveInt64("shard_id", "-${CONFIGREF_NUMBER}"); err != nil || n != -42 {
+		t.Errorf("unexpected int64: %v, %v", n, err)
+	}
+	if f, err := ResolveFloat64("gas_adjustment", "1.${CONFIGREF_NUMBER}"); err != nil || f != 1.42 {
+		t.Errorf("unexpected float64: %v, %v", f, err)
+	}
+
+	// a value which isn't a number is left out of the error
+	for _, resolve := range []func(string, string) error{
+		func(name, ref string) error { _, err := ResolveUint64(name, ref); return err },
+		func(name, ref string) error { _, err := ResolveInt64(name, ref); return err },
+		func(name, ref string) error { _, err := ResolveFloat64(name, ref); return err },
+	} {
+		err := resolve("number", "${CONFIGREF_KEY}")
+		if err == nil || !strings.Contains(err.Error(), "number") || strings.Contains(err.Error(), testSecret) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
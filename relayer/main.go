@@ -5,6 +5,7 @@ import (
 
 	harmony "github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/harmony/module"
 	tendermint "github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/chains/tendermint/module"
+	clients "github.com/mapdev33/harmony-cosmos-bridge-demo/relayer/clients/module"
 	"github.com/mapdev33/yui-relayer/cmd"
 	mock "github.com/mapdev33/yui-relayer/provers/mock/module"
 )
@@ -14,6 +15,7 @@ func main() {
 		harmony.Module{},
 		tendermint.Module{},
 		mock.Module{},
+		clients.Module{},
 	); err != nil {
 		log.Fatal(err)
 	}
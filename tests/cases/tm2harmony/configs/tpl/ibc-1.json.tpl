@@ -14,7 +14,7 @@
     "ics20_bank_address": "",
     "ics20_transfer_bank_address": "",
     "gas_limit": 5000000,
-    "gas_price": 1000000000
+    "gas_price": 100
   },
   "prover": {
     "@type": "/relayer.chains.harmony.config.ProverConfig",